@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sieniven/zkevm-nubit/config"
+	"github.com/sieniven/zkevm-nubit/etherman"
+	"github.com/sieniven/zkevm-nubit/etherman/smartcontracts/nubitdaverifier"
+	polygonzkevm "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonvalidium_xlayer"
+	"github.com/urfave/cli/v2"
+)
+
+var daAddressFlag = cli.StringFlag{
+	Name:  config.FlagDAAddress,
+	Usage: "`ADDRESS` of the deployed data availability protocol contract",
+}
+
+var deployNubitFlag = cli.BoolFlag{
+	Name:  config.FlagDeployNubit,
+	Usage: "Deploy the NubitDA verifier and set it as the data availability protocol",
+}
+
+var sequencerFlag = cli.StringFlag{
+	Name:  config.FlagSequencer,
+	Usage: "`ADDRESS` signing the NubitDA messages accepted by the deployed verifier, the configured DA signer by default",
+}
+
+var allowedFlag = cli.BoolFlag{
+	Name:  config.FlagAllowed,
+	Usage: "Whether the trusted sequencer may sequence batches with their data in the calldata, the current value is toggled if not set",
+}
+
+var fromFlag = cli.StringFlag{
+	Name:  config.FlagFrom,
+	Usage: "Sender `ADDRESS` of the dry run txs, the configured L1 signer by default",
+}
+
+var dryRunFlag = cli.BoolFlag{
+	Name:  config.FlagDryRun,
+	Usage: "Only estimate the gas of the txs, nothing is sent",
+}
+
+var timeoutFlag = cli.DurationFlag{
+	Name:  config.FlagTimeout,
+	Usage: "Time to wait for each tx to be mined",
+	Value: 5 * time.Minute, //nolint:gomnd
+}
+
+// daProtocolCommand is the command group administrating the data availability protocol of the
+// rollup on L1. The txs are sent by the configured L1 signer, which must be the rollup admin.
+var daProtocolCommand = &cli.Command{
+	Name:  "da-protocol",
+	Usage: "Administrate the data availability protocol of the rollup on L1",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "show",
+			Usage:  "Print the data availability protocol of the rollup",
+			Action: showDAProtocol,
+			Flags:  []cli.Flag{&configFileFlag, &networkJsonFlag},
+		},
+		{
+			Name:   "set",
+			Usage:  "Set the data availability protocol of the rollup, deploying the NubitDA verifier if requested",
+			Action: setDAProtocol,
+			Flags: []cli.Flag{&configFileFlag, &networkJsonFlag, &daAddressFlag, &deployNubitFlag, &sequencerFlag,
+				&fromFlag, &dryRunFlag, &timeoutFlag},
+		},
+		{
+			Name:   "switch-sequence-with-da",
+			Usage:  "Allow or forbid the trusted sequencer to sequence batches with their data in the calldata",
+			Action: switchSequenceWithDA,
+			Flags:  []cli.Flag{&configFileFlag, &networkJsonFlag, &allowedFlag, &fromFlag, &dryRunFlag, &timeoutFlag},
+		},
+	},
+}
+
+// adminTxResult is the outcome of an admin tx of the rollup
+type adminTxResult struct {
+	Method       string
+	DryRun       bool
+	EstimatedGas uint64
+	// TxHash, BlockNumber and GasUsed are only set once the tx is mined
+	TxHash      common.Hash
+	BlockNumber uint64
+	GasUsed     uint64
+	// ContractAddress is the address of the deployed contract, predicted on a dry run
+	ContractAddress *common.Address
+	Events          []adminTxEvent
+}
+
+// adminTxEvent is an event emitted by an admin tx
+type adminTxEvent struct {
+	Name    string
+	Address common.Address
+	Args    []adminTxEventArg
+}
+
+// adminTxEventArg is an argument of an event, in the order of the event signature
+type adminTxEventArg struct {
+	Name  string
+	Value interface{}
+}
+
+// String prints the result in a human readable form
+func (r *adminTxResult) String() string {
+	var sb strings.Builder
+	if r.DryRun {
+		fmt.Fprintf(&sb, "Dry run of %s, estimated gas: %d\n", r.Method, r.EstimatedGas)
+	} else {
+		fmt.Fprintf(&sb, "%s tx %v mined in block %d, gas used: %d\n", r.Method, r.TxHash, r.BlockNumber, r.GasUsed)
+	}
+	if r.ContractAddress != nil {
+		fmt.Fprintf(&sb, "Contract address: %v\n", *r.ContractAddress)
+	}
+	for _, event := range r.Events {
+		args := make([]string, 0, len(event.Args))
+		for _, arg := range event.Args {
+			args = append(args, fmt.Sprintf("%s=%v", arg.Name, arg.Value))
+		}
+		fmt.Fprintf(&sb, "Event %s(%s) emitted by %v\n", event.Name, strings.Join(args, ", "), event.Address)
+	}
+	return sb.String()
+}
+
+func showDAProtocol(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+	etherMan, err := newEtherman(*c)
+	if err != nil {
+		return err
+	}
+
+	address, err := etherMan.GetDAProtocolAddr()
+	if err != nil {
+		return fmt.Errorf("failed to get the data availability protocol: %w", err)
+	}
+	fmt.Printf("Data availability protocol: %v\n", address)
+	name, err := etherMan.GetDAProtocolName()
+	if err != nil {
+		fmt.Printf("Data availability protocol name: unknown (%v)\n", err)
+	} else {
+		fmt.Printf("Data availability protocol name: %s\n", name)
+	}
+	allowed, err := etherMan.IsSequenceWithDataAvailabilityAllowed()
+	if err != nil {
+		return fmt.Errorf("failed to get whether sequencing with the data availability is allowed: %w", err)
+	}
+	fmt.Printf("Sequence with data availability allowed: %v\n", allowed)
+	return nil
+}
+
+func setDAProtocol(cliCtx *cli.Context) error {
+	if cliCtx.IsSet(config.FlagDAAddress) == cliCtx.Bool(config.FlagDeployNubit) {
+		return fmt.Errorf("either --%s or --%s is required", config.FlagDAAddress, config.FlagDeployNubit)
+	}
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+	etherMan, err := newEtherman(*c)
+	if err != nil {
+		return err
+	}
+	from, err := loadAdminSender(cliCtx, *c, etherMan)
+	if err != nil {
+		return err
+	}
+	dryRun, timeout := cliCtx.Bool(config.FlagDryRun), cliCtx.Duration(config.FlagTimeout)
+
+	daAddress := common.HexToAddress(cliCtx.String(config.FlagDAAddress))
+	if cliCtx.Bool(config.FlagDeployNubit) {
+		var sequencer common.Address
+		if cliCtx.IsSet(config.FlagSequencer) {
+			sequencer = common.HexToAddress(cliCtx.String(config.FlagSequencer))
+		} else {
+			daSigner, err := etherMan.LoadSigner(c.SequenceSender.DASigner, c.SequenceSender.DAPermitApiPrivateKey)
+			if err != nil {
+				return fmt.Errorf("failed to load the DA signer, use --%s to set the sequencer: %w", config.FlagSequencer, err)
+			}
+			sequencer = daSigner.Address()
+		}
+		result, err := deployNubitDAVerifier(cliCtx.Context, etherMan, from, sequencer, dryRun, timeout)
+		if err != nil {
+			return err
+		}
+		fmt.Print(result)
+		daAddress = *result.ContractAddress
+	}
+
+	result, err := sendSetDAProtocol(cliCtx.Context, etherMan, from, daAddress, dryRun, timeout)
+	if err != nil {
+		return err
+	}
+	fmt.Print(result)
+	return nil
+}
+
+func switchSequenceWithDA(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+	etherMan, err := newEtherman(*c)
+	if err != nil {
+		return err
+	}
+	from, err := loadAdminSender(cliCtx, *c, etherMan)
+	if err != nil {
+		return err
+	}
+
+	current, err := etherMan.IsSequenceWithDataAvailabilityAllowed()
+	if err != nil {
+		return fmt.Errorf("failed to get whether sequencing with the data availability is allowed: %w", err)
+	}
+	allowed := !current
+	if cliCtx.IsSet(config.FlagAllowed) {
+		allowed = cliCtx.Bool(config.FlagAllowed)
+	}
+	result, err := sendSwitchSequenceWithDA(cliCtx.Context, etherMan, from, current, allowed,
+		cliCtx.Bool(config.FlagDryRun), cliCtx.Duration(config.FlagTimeout))
+	if err != nil {
+		return err
+	}
+	fmt.Print(result)
+	return nil
+}
+
+// loadAdminSender returns the sender of the admin txs, the configured L1 signer. A dry run does
+// not sign, so the sender can be given instead.
+func loadAdminSender(cliCtx *cli.Context, c config.Config, etherMan *etherman.Client) (common.Address, error) {
+	if cliCtx.IsSet(config.FlagFrom) {
+		if !cliCtx.Bool(config.FlagDryRun) {
+			return common.Address{}, fmt.Errorf("--%s is only allowed with --%s", config.FlagFrom, config.FlagDryRun)
+		}
+		return common.HexToAddress(cliCtx.String(config.FlagFrom)), nil
+	}
+	s, err := etherMan.LoadSigner(c.Signer, c.Key)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to load the L1 signer: %w", err)
+	}
+	return s.Address(), nil
+}
+
+// deployNubitDAVerifier deploys the NubitDA verifier accepting the messages signed by the sequencer
+func deployNubitDAVerifier(ctx context.Context, etherMan *etherman.Client, from, sequencer common.Address,
+	dryRun bool, timeout time.Duration) (*adminTxResult, error) {
+	address, tx, err := etherMan.DeployNubitDAVerifier(from, sequencer, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy the NubitDA verifier: %w", err)
+	}
+	result, err := waitAdminTx(ctx, etherMan, "deploy NubitDAVerifier", tx, dryRun, timeout)
+	if err != nil {
+		return nil, err
+	}
+	result.ContractAddress = &address
+	return result, nil
+}
+
+// sendSetDAProtocol sets the data availability protocol of the rollup
+func sendSetDAProtocol(ctx context.Context, etherMan *etherman.Client, from, daAddress common.Address,
+	dryRun bool, timeout time.Duration) (*adminTxResult, error) {
+	tx, err := etherMan.SetDataAvailabilityProtocol(from, daAddress, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set the data availability protocol: %w", err)
+	}
+	return waitAdminTx(ctx, etherMan, "setDataAvailabilityProtocol", tx, dryRun, timeout)
+}
+
+// sendSwitchSequenceWithDA switches whether the trusted sequencer may sequence batches with their
+// data in the calldata. The rollup reverts when the value is unchanged, so it is checked first.
+func sendSwitchSequenceWithDA(ctx context.Context, etherMan *etherman.Client, from common.Address, current, allowed bool,
+	dryRun bool, timeout time.Duration) (*adminTxResult, error) {
+	if current == allowed {
+		return nil, fmt.Errorf("sequence with data availability allowed is already %v", allowed)
+	}
+	tx, err := etherMan.SwitchSequenceWithDataAvailability(from, allowed, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to switch sequence with data availability: %w", err)
+	}
+	return waitAdminTx(ctx, etherMan, "switchSequenceWithDataAvailability", tx, dryRun, timeout)
+}
+
+// waitAdminTx waits for the admin tx to be mined and decodes the events it emitted. On a dry run
+// the tx was not sent, only its estimated gas is returned.
+func waitAdminTx(ctx context.Context, etherMan *etherman.Client, method string, tx *types.Transaction,
+	dryRun bool, timeout time.Duration) (*adminTxResult, error) {
+	result := &adminTxResult{Method: method, DryRun: dryRun, EstimatedGas: tx.Gas()}
+	if dryRun {
+		return result, nil
+	}
+
+	mined, err := etherMan.WaitTxToBeMined(ctx, tx, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("%s tx %v failed: %w", method, tx.Hash(), err)
+	}
+	if !mined {
+		return nil, fmt.Errorf("%s tx %v not mined after %v", method, tx.Hash(), timeout)
+	}
+	receipt, err := etherMan.GetTxReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the receipt of tx %v: %w", tx.Hash(), err)
+	}
+	result.TxHash = tx.Hash()
+	result.BlockNumber = receipt.BlockNumber.Uint64()
+	result.GasUsed = receipt.GasUsed
+	result.Events, err = decodeAdminEvents(receipt.Logs)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// decodeAdminEvents decodes the logs emitted by the rollup and the NubitDA verifier, the other
+// logs are skipped
+func decodeAdminEvents(logs []*types.Log) ([]adminTxEvent, error) {
+	var abis []*abi.ABI
+	for _, metaData := range []interface{ GetAbi() (*abi.ABI, error) }{
+		polygonzkevm.PolygonvalidiumXlayerMetaData, nubitdaverifier.NubitdaverifierMetaData,
+	} {
+		parsed, err := metaData.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		abis = append(abis, parsed)
+	}
+
+	var events []adminTxEvent
+	for _, l := range logs {
+		if len(l.Topics) == 0 {
+			continue
+		}
+		for _, contractAbi := range abis {
+			event, err := contractAbi.EventByID(l.Topics[0])
+			if err != nil {
+				continue
+			}
+			values := map[string]interface{}{}
+			if err := contractAbi.UnpackIntoMap(values, event.Name, l.Data); err != nil {
+				return nil, fmt.Errorf("failed to decode event %s: %w", event.Name, err)
+			}
+			var indexed abi.Arguments
+			for _, input := range event.Inputs {
+				if input.Indexed {
+					indexed = append(indexed, input)
+				}
+			}
+			if err := abi.ParseTopicsIntoMap(values, indexed, l.Topics[1:]); err != nil {
+				return nil, fmt.Errorf("failed to decode event %s: %w", event.Name, err)
+			}
+			decoded := adminTxEvent{Name: event.Name, Address: l.Address}
+			for _, input := range event.Inputs {
+				decoded.Args = append(decoded.Args, adminTxEventArg{Name: input.Name, Value: values[input.Name]})
+			}
+			events = append(events, decoded)
+			break
+		}
+	}
+	return events, nil
+}
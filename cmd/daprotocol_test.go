@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sieniven/zkevm-nubit/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTimeout = 5 * time.Second
+
+func TestSetDAProtocolDeployingNubitDAVerifier(t *testing.T) {
+	ctx := context.Background()
+	l1 := testutil.NewSimulatedL1(t, testutil.Config{})
+	l1.Mine(t, 10*time.Millisecond)
+	admin := crypto.PubkeyToAddress(l1.Admin.PublicKey)
+	sequencer := common.HexToAddress("0x1234")
+
+	deployed, err := deployNubitDAVerifier(ctx, l1.Etherman, admin, sequencer, false, testTimeout)
+	require.NoError(t, err)
+	require.NotNil(t, deployed.ContractAddress)
+	assert.NotZero(t, deployed.BlockNumber)
+
+	result, err := sendSetDAProtocol(ctx, l1.Etherman, admin, *deployed.ContractAddress, false, testTimeout)
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, "SetDataAvailabilityProtocol", result.Events[0].Name)
+	assert.Equal(t, l1.L1Config.ZkEVMAddr, result.Events[0].Address)
+	assert.Equal(t, []adminTxEventArg{{Name: "newDataAvailabilityProtocol", Value: *deployed.ContractAddress}}, result.Events[0].Args)
+	assert.Contains(t, result.String(), "Event SetDataAvailabilityProtocol(newDataAvailabilityProtocol="+deployed.ContractAddress.Hex()+")")
+
+	address, err := l1.Etherman.GetDAProtocolAddr()
+	require.NoError(t, err)
+	assert.Equal(t, *deployed.ContractAddress, address)
+	name, err := l1.Etherman.GetDAProtocolName()
+	require.NoError(t, err)
+	assert.Equal(t, "NubitDA", name)
+}
+
+func TestSetDAProtocolDryRun(t *testing.T) {
+	ctx := context.Background()
+	l1 := testutil.NewSimulatedL1(t, testutil.Config{FundedKeys: 1})
+	admin := crypto.PubkeyToAddress(l1.Admin.PublicKey)
+
+	deployed, err := deployNubitDAVerifier(ctx, l1.Etherman, admin, admin, true, testTimeout)
+	require.NoError(t, err)
+	assert.True(t, deployed.DryRun)
+	assert.NotZero(t, deployed.EstimatedGas)
+	require.NotNil(t, deployed.ContractAddress)
+	result, err := sendSetDAProtocol(ctx, l1.Etherman, admin, *deployed.ContractAddress, true, testTimeout)
+	require.NoError(t, err)
+	assert.NotZero(t, result.EstimatedGas)
+	assert.Empty(t, result.Events)
+	assert.Contains(t, result.String(), "Dry run of setDataAvailabilityProtocol, estimated gas:")
+
+	// Nothing was sent
+	l1.Backend.Commit()
+	address, err := l1.Etherman.GetDAProtocolAddr()
+	require.NoError(t, err)
+	assert.Equal(t, l1.DAProtocol, address)
+	code, err := l1.Backend.CodeAt(ctx, *deployed.ContractAddress, nil)
+	require.NoError(t, err)
+	assert.Empty(t, code)
+
+	// Only the admin sets the data availability protocol
+	_, err = sendSetDAProtocol(ctx, l1.Etherman, crypto.PubkeyToAddress(l1.Keys[0].PublicKey), *deployed.ContractAddress, true, testTimeout)
+	assert.Error(t, err)
+}
+
+func TestSwitchSequenceWithDA(t *testing.T) {
+	ctx := context.Background()
+	l1 := testutil.NewSimulatedL1(t, testutil.Config{})
+	l1.Mine(t, 10*time.Millisecond)
+	admin := crypto.PubkeyToAddress(l1.Admin.PublicKey)
+	allowed, err := l1.Etherman.IsSequenceWithDataAvailabilityAllowed()
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	_, err = sendSwitchSequenceWithDA(ctx, l1.Etherman, admin, false, false, false, testTimeout)
+	assert.ErrorContains(t, err, "already false")
+
+	result, err := sendSwitchSequenceWithDA(ctx, l1.Etherman, admin, false, true, true, testTimeout)
+	require.NoError(t, err)
+	assert.NotZero(t, result.EstimatedGas)
+	allowed, err = l1.Etherman.IsSequenceWithDataAvailabilityAllowed()
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	result, err = sendSwitchSequenceWithDA(ctx, l1.Etherman, admin, false, true, false, testTimeout)
+	require.NoError(t, err)
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, "SwitchSequenceWithDataAvailability", result.Events[0].Name)
+	assert.Empty(t, result.Events[0].Args)
+	allowed, err = l1.Etherman.IsSequenceWithDataAvailabilityAllowed()
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
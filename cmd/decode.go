@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sieniven/zkevm-nubit/config"
+	"github.com/sieniven/zkevm-nubit/dataavailability"
+	"github.com/sieniven/zkevm-nubit/dataavailability/ethblob"
+	"github.com/sieniven/zkevm-nubit/dataavailability/nubit"
+	"github.com/sieniven/zkevm-nubit/etherman"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/urfave/cli/v2"
+)
+
+// nubitSignatureLength is the length of the NubitDA message signature field, holding the
+// signed sequence hash followed by the [R || S || V] signature
+const nubitSignatureLength = common.HashLength + crypto.SignatureLength
+
+var txHashFlag = cli.StringFlag{
+	Name:  config.FlagTxHash,
+	Usage: "Hash of the L1 sequenceBatchesValidium `TX` to decode",
+}
+
+var calldataFlag = cli.StringFlag{
+	Name:  config.FlagCalldata,
+	Usage: "Hex encoded `CALLDATA` of the sequenceBatchesValidium tx to decode",
+}
+
+var lastBatchFlag = cli.Uint64Flag{
+	Name:  config.FlagLastBatch,
+	Usage: "`NUMBER` of the last batch of the sequence, read from the L1 tx event or the elderberry calldata if not set",
+}
+
+var fetchFlag = cli.BoolFlag{
+	Name:  config.FlagFetch,
+	Usage: "Fetch the batches data from the DA layer and verify it against the batch hashes",
+}
+
+// decodeDACommand decodes the data availability message committed by a sequenceBatchesValidium tx
+var decodeDACommand = &cli.Command{
+	Name:   "decode-da",
+	Usage:  "Decode the data availability message of a sequenceBatchesValidium tx",
+	Action: decodeDA,
+	Flags:  []cli.Flag{&configFileFlag, &networkJsonFlag, &txHashFlag, &calldataFlag, &lastBatchFlag, &fetchFlag},
+}
+
+// decodedSequence is the sequence committed by a sequenceBatchesValidium tx
+type decodedSequence struct {
+	TxHash    *common.Hash   `json:"txHash,omitempty"`
+	Sequencer common.Address `json:"sequencer"`
+	Coinbase  common.Address `json:"coinbase"`
+	Batches   []decodedBatch `json:"batches"`
+
+	DABackend dataavailability.DABackendType `json:"daBackend"`
+	DAMessage hexutil.Bytes                  `json:"daMessage,omitempty"`
+	// BlobIDs are the NubitDA blob IDs or the Ethereum blob versioned hashes of the sequence
+	BlobIDs    []hexutil.Bytes `json:"blobIds,omitempty"`
+	BlobTxHash *common.Hash    `json:"blobTxHash,omitempty"`
+
+	Signer         *common.Address `json:"signer,omitempty"`
+	SignedHash     *common.Hash    `json:"signedHash,omitempty"`
+	Signature      hexutil.Bytes   `json:"signature,omitempty"`
+	SignatureValid *bool           `json:"signatureValid,omitempty"`
+	SignatureError string          `json:"signatureError,omitempty"`
+
+	FetchError string `json:"fetchError,omitempty"`
+}
+
+// decodedBatch is a batch of the decoded sequence
+type decodedBatch struct {
+	// BatchNumber is zero when the number of the last batch of the sequence is unknown
+	BatchNumber      uint64      `json:"batchNumber,omitempty"`
+	TransactionsHash common.Hash `json:"transactionsHash"`
+	Forced           bool        `json:"forced"`
+	// DataVerified is only set when the batch data was fetched from the DA layer
+	DataVerified *bool         `json:"dataVerified,omitempty"`
+	Data         hexutil.Bytes `json:"data,omitempty"`
+}
+
+func decodeDA(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	var (
+		calldata        []byte
+		txHash          common.Hash
+		sequencer       common.Address
+		nonce           uint64
+		lastBatchNumber = cliCtx.Uint64(config.FlagLastBatch)
+		l1InfoRoot      common.Hash
+		etherMan        *etherman.Client
+	)
+	switch {
+	case cliCtx.IsSet(config.FlagTxHash) == cliCtx.IsSet(config.FlagCalldata):
+		return fmt.Errorf("either --%s or --%s is required", config.FlagTxHash, config.FlagCalldata)
+	case cliCtx.IsSet(config.FlagCalldata):
+		calldata, err = hexutil.Decode(cliCtx.String(config.FlagCalldata))
+		if err != nil {
+			return fmt.Errorf("invalid calldata: %w", err)
+		}
+	default:
+		txHash = common.HexToHash(cliCtx.String(config.FlagTxHash))
+		etherMan, err = newEtherman(*c)
+		if err != nil {
+			return err
+		}
+		tx, _, err := etherMan.GetTx(cliCtx.Context, txHash)
+		if err != nil {
+			return fmt.Errorf("failed to get tx %v: %w", txHash, err)
+		}
+		sequencer, err = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return fmt.Errorf("failed to get sender of tx %v: %w", txHash, err)
+		}
+		calldata, nonce = tx.Data(), tx.Nonce()
+		eventLastBatch, eventL1InfoRoot, err := etherMan.GetSequencedBatchesEvent(cliCtx.Context, txHash)
+		if err != nil && !errors.Is(err, etherman.ErrNotFound) {
+			return fmt.Errorf("failed to get SequenceBatches event of tx %v: %w", txHash, err)
+		}
+		if err == nil && lastBatchNumber == 0 {
+			lastBatchNumber, l1InfoRoot = eventLastBatch, eventL1InfoRoot
+		}
+	}
+
+	var da dataavailability.BatchDataProvider
+	if cliCtx.Bool(config.FlagFetch) {
+		if etherMan == nil {
+			etherMan, err = newEtherman(*c)
+			if err != nil {
+				return err
+			}
+		}
+		// the monitored txs of the running node are left untouched
+		etmCfg := c.EthTxManager
+		etmCfg.StorageType = ethtxmanager.StorageTypeMemory
+		etm, err := ethtxmanager.New(etmCfg, etherMan)
+		if err != nil {
+			return err
+		}
+		da, err = newDataAvailability(cliCtx.Context, *c, etherMan, etm)
+		if err != nil {
+			return err
+		}
+	}
+
+	decoded, err := decodeSequence(c.DABackendType, calldata, lastBatchNumber, sequencer, txHash, nonce, l1InfoRoot, da)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(decoded, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// decodeSequence decodes the sequence committed by the calldata, and its data availability message
+// for the DA backend. When a data provider is given, the batches data is fetched and verified.
+func decodeSequence(backend dataavailability.DABackendType, calldata []byte, lastBatchNumber uint64, sequencer common.Address,
+	txHash common.Hash, nonce uint64, l1InfoRoot common.Hash, da dataavailability.BatchDataProvider) (*decodedSequence, error) {
+	// the batch numbers are computed back from the last one, use a placeholder when it is unknown
+	knownBatchNumbers := lastBatchNumber != 0
+	if !knownBatchNumbers {
+		lastBatchNumber = ^uint64(0)
+	}
+	provider := &recordingDataProvider{da: da}
+	batches, err := etherman.DecodeSequencedBatches(calldata, lastBatchNumber, sequencer, txHash, nonce, l1InfoRoot, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &decodedSequence{
+		Sequencer: sequencer,
+		DABackend: backend,
+		DAMessage: provider.dataAvailabilityMessage,
+		Batches:   make([]decodedBatch, 0, len(batches)),
+	}
+	if txHash != (common.Hash{}) {
+		decoded.TxHash = &txHash
+	}
+	for i, batch := range batches {
+		decoded.Coinbase = batch.Coinbase
+		b := decodedBatch{
+			Forced: batch.ForcedTimestamp > 0,
+		}
+		switch {
+		case knownBatchNumbers:
+			b.BatchNumber = batch.BatchNumber
+		case batch.SequencedBatchElderberryData != nil:
+			b.BatchNumber = batch.InitSequencedBatchNumber + uint64(i) + 1
+		}
+		if provider.called {
+			b.TransactionsHash = provider.batchHashes[i]
+			if provider.fetched {
+				verified := crypto.Keccak256Hash(batch.Transactions) == b.TransactionsHash
+				b.DataVerified = &verified
+				b.Data = batch.Transactions
+			}
+		} else {
+			// rollup batches carry their data in the calldata
+			b.TransactionsHash = crypto.Keccak256Hash(batch.Transactions)
+			b.Data = batch.Transactions
+		}
+		decoded.Batches = append(decoded.Batches, b)
+	}
+	if provider.fetchErr != nil {
+		decoded.FetchError = provider.fetchErr.Error()
+	}
+	if !provider.called {
+		return decoded, nil
+	}
+
+	switch backend {
+	case dataavailability.Nubit:
+		blobData, err := nubit.TryDecodeFromDataAvailabilityMessage(provider.dataAvailabilityMessage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode NubitDA message: %w", err)
+		}
+		decoded.BlobIDs = []hexutil.Bytes{blobData.BlobID}
+		decoded.verifyNubitSignature(blobData.Signature)
+	case dataavailability.EthereumBlobs:
+		blobData, err := ethblob.TryDecodeFromDataAvailabilityMessage(provider.dataAvailabilityMessage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Ethereum blobs message: %w", err)
+		}
+		blobTxHash := common.Hash(blobData.TxHash)
+		decoded.BlobTxHash = &blobTxHash
+		for _, h := range blobData.Hashes() {
+			decoded.BlobIDs = append(decoded.BlobIDs, h.Bytes())
+		}
+	default:
+		return nil, fmt.Errorf("unexpected / unsupported DA protocol: %s", backend)
+	}
+	return decoded, nil
+}
+
+// verifyNubitSignature recovers the signer of the NubitDA message signature, and checks that the
+// signed hash commits to the hashes of the batches posted to the DA layer
+func (d *decodedSequence) verifyNubitSignature(signature []byte) {
+	valid := false
+	d.SignatureValid = &valid
+	if len(signature) != nubitSignatureLength {
+		d.SignatureError = fmt.Sprintf("invalid signature length %d, expected %d", len(signature), nubitSignatureLength)
+		return
+	}
+	signedHash := common.BytesToHash(signature[:common.HashLength])
+	d.SignedHash = &signedHash
+	d.Signature = signature[common.HashLength:]
+
+	sig := common.CopyBytes(d.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 { //nolint:gomnd
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(signedHash.Bytes(), sig)
+	if err != nil {
+		d.SignatureError = fmt.Sprintf("failed to recover signer: %v", err)
+		return
+	}
+	signer := crypto.PubkeyToAddress(*pubKey)
+	d.Signer = &signer
+
+	// the forced batches are not posted to the DA layer
+	expectedHash := common.Hash{}
+	for _, batch := range d.Batches {
+		if !batch.Forced {
+			expectedHash = crypto.Keccak256Hash(expectedHash.Bytes(), batch.TransactionsHash.Bytes())
+		}
+	}
+	if !bytes.Equal(expectedHash.Bytes(), signedHash.Bytes()) {
+		d.SignatureError = fmt.Sprintf("signed hash does not match the batch hashes, expected %v", expectedHash)
+		return
+	}
+	valid = true
+}
+
+// recordingDataProvider records the validium batch hashes and the data availability message
+// requested while decoding a sequence. The batches data is only fetched if a data provider is
+// set, otherwise empty data is returned.
+type recordingDataProvider struct {
+	da dataavailability.BatchDataProvider
+
+	called                  bool
+	fetched                 bool
+	fetchErr                error
+	batchHashes             []common.Hash
+	dataAvailabilityMessage []byte
+}
+
+// GetBatchL2Data records the request and returns the data from the data provider if set. A
+// failure to fetch is recorded instead of returned, so the sequence is decoded anyway.
+func (p *recordingDataProvider) GetBatchL2Data(batchNums []uint64, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
+	p.called = true
+	p.batchHashes = batchHashes
+	p.dataAvailabilityMessage = dataAvailabilityMessage
+
+	empty := make([][]byte, len(batchHashes))
+	if p.da == nil {
+		return empty, nil
+	}
+	data, err := p.da.GetBatchL2Data(batchNums, batchHashes, dataAvailabilityMessage)
+	if err == nil && len(data) != len(batchHashes) {
+		err = fmt.Errorf("DA layer returned %d batches, expected %d", len(data), len(batchHashes))
+	}
+	if err != nil {
+		p.fetchErr = err
+		return empty, nil
+	}
+	p.fetched = true
+	return data, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sieniven/zkevm-nubit/dataavailability"
+	"github.com/sieniven/zkevm-nubit/dataavailability/ethblob"
+	"github.com/sieniven/zkevm-nubit/dataavailability/nubit"
+	polygonzkevm "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonvalidium_xlayer"
+	"github.com/sieniven/zkevm-nubit/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBatchesData = [][]byte{{1, 2, 3}, {4, 5}, {6}}
+
+// stubDataProvider returns the batches data regardless of the request
+type stubDataProvider struct {
+	data [][]byte
+}
+
+func (p *stubDataProvider) GetBatchL2Data(batchNums []uint64, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
+	return p.data, nil
+}
+
+// newTestingCalldata packs the elderberry sequenceBatchesValidium calldata of the batches, the last
+// one being a forced batch
+func newTestingCalldata(t *testing.T, initBatchNumber uint64, coinbase common.Address, dataAvailabilityMessage []byte) []byte {
+	t.Helper()
+	smcAbi, err := abi.JSON(strings.NewReader(polygonzkevm.PolygonvalidiumXlayerABI))
+	require.NoError(t, err)
+
+	batches := make([]polygonzkevm.PolygonValidiumEtrogValidiumBatchData, 0, len(testBatchesData))
+	for i, data := range testBatchesData {
+		batch := polygonzkevm.PolygonValidiumEtrogValidiumBatchData{TransactionsHash: crypto.Keccak256Hash(data)}
+		if i == len(testBatchesData)-1 {
+			batch.ForcedTimestamp = 1
+		}
+		batches = append(batches, batch)
+	}
+	calldata, err := smcAbi.Pack("sequenceBatchesValidium", batches, uint64(100), initBatchNumber, coinbase, dataAvailabilityMessage)
+	require.NoError(t, err)
+	return calldata
+}
+
+// newTestingNubitMessage signs the non forced batches as the NubitDA backend does
+func newTestingNubitMessage(t *testing.T, s signer.Signer, batchesData [][]byte) []byte {
+	t.Helper()
+	sequence := daTypes.Sequence{}
+	for _, data := range batchesData {
+		sequence = append(sequence, data)
+	}
+	signedSequence, err := signer.SignSequence(context.Background(), s, sequence)
+	require.NoError(t, err)
+	msg, err := nubit.TryEncodeToDataAvailabilityMessage(nubit.BlobData{
+		BlobID:    []byte{0xb1, 0x0b},
+		Signature: append(sequence.HashToSign(), signedSequence.Signature...),
+	})
+	require.NoError(t, err)
+	return msg
+}
+
+func TestDecodeNubitSequence(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := signer.NewPrivateKeySigner(key, 1)
+	coinbase := common.HexToAddress("0xc0")
+	msg := newTestingNubitMessage(t, s, testBatchesData[:2])
+	calldata := newTestingCalldata(t, 10, coinbase, msg)
+
+	decoded, err := decodeSequence(dataavailability.Nubit, calldata, 0, common.Address{}, common.Hash{}, 0, common.Hash{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, coinbase, decoded.Coinbase)
+	assert.Equal(t, []byte{0xb1, 0x0b}, []byte(decoded.BlobIDs[0]))
+	require.NotNil(t, decoded.Signer)
+	assert.Equal(t, s.Address(), *decoded.Signer)
+	require.NotNil(t, decoded.SignatureValid)
+	assert.True(t, *decoded.SignatureValid, decoded.SignatureError)
+	require.Len(t, decoded.Batches, len(testBatchesData))
+	for i, batch := range decoded.Batches {
+		assert.Equal(t, uint64(11+i), batch.BatchNumber)
+		assert.Equal(t, crypto.Keccak256Hash(testBatchesData[i]), batch.TransactionsHash)
+		assert.Equal(t, i == len(testBatchesData)-1, batch.Forced)
+		assert.Nil(t, batch.DataVerified)
+	}
+
+	// The signature commits to other batches
+	calldata = newTestingCalldata(t, 10, coinbase, newTestingNubitMessage(t, s, testBatchesData[1:]))
+	decoded, err = decodeSequence(dataavailability.Nubit, calldata, 0, common.Address{}, common.Hash{}, 0, common.Hash{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), *decoded.Signer)
+	assert.False(t, *decoded.SignatureValid)
+	assert.NotEmpty(t, decoded.SignatureError)
+}
+
+func TestDecodeFetchedSequence(t *testing.T) {
+	txHash, versionedHash := common.HexToHash("0x1"), common.HexToHash("0x2")
+	msg, err := ethblob.TryEncodeToDataAvailabilityMessage(ethblob.BlobData{TxHash: txHash, VersionedHashes: [][32]byte{versionedHash}})
+	require.NoError(t, err)
+	calldata := newTestingCalldata(t, 10, common.Address{}, msg)
+
+	decoded, err := decodeSequence(dataavailability.EthereumBlobs, calldata, 20, common.Address{}, common.Hash{}, 0, common.Hash{}, &stubDataProvider{data: testBatchesData})
+	require.NoError(t, err)
+	assert.Equal(t, txHash, *decoded.BlobTxHash)
+	assert.Equal(t, versionedHash.Bytes(), []byte(decoded.BlobIDs[0]))
+	assert.Nil(t, decoded.SignatureValid)
+	for i, batch := range decoded.Batches {
+		assert.Equal(t, uint64(18+i), batch.BatchNumber)
+		require.NotNil(t, batch.DataVerified)
+		assert.True(t, *batch.DataVerified)
+		assert.Equal(t, testBatchesData[i], []byte(batch.Data))
+	}
+
+	// The DA layer returns data not matching the batch hashes
+	wrongData := [][]byte{{1}, {2}, {3}}
+	decoded, err = decodeSequence(dataavailability.EthereumBlobs, calldata, 20, common.Address{}, common.Hash{}, 0, common.Hash{}, &stubDataProvider{data: wrongData})
+	require.NoError(t, err)
+	for _, batch := range decoded.Batches {
+		assert.False(t, *batch.DataVerified)
+	}
+
+	// The DA layer does not return all the batches
+	decoded, err = decodeSequence(dataavailability.EthereumBlobs, calldata, 20, common.Address{}, common.Hash{}, 0, common.Hash{}, &stubDataProvider{data: wrongData[:1]})
+	require.NoError(t, err)
+	assert.NotEmpty(t, decoded.FetchError)
+	assert.Nil(t, decoded.Batches[0].DataVerified)
+}
+
+func TestDecodeInvalidCalldata(t *testing.T) {
+	_, err := decodeSequence(dataavailability.Nubit, []byte{1, 2}, 0, common.Address{}, common.Hash{}, 0, common.Hash{}, nil)
+	require.Error(t, err)
+	_, err = decodeSequence(dataavailability.Nubit, []byte{1, 2, 3, 4, 5}, 0, common.Address{}, common.Hash{}, 0, common.Hash{}, nil)
+	require.Error(t, err)
+}
@@ -1,22 +1,285 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/sieniven/zkevm-nubit/config"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
 )
 
-func createKeystore(cliCtx *cli.Context) error {
-	ks := keystore.NewKeyStore("./wallets", keystore.StandardScryptN, keystore.StandardScryptP)
-	password := cliCtx.String(config.FlagPassword)
+// minPasswordLength is the minimum length of the keystore passwords accepted without --insecure
+const minPasswordLength = 8
 
-	account, err := ks.NewAccount(password)
+// weakPasswords are the well known passwords refused without --insecure
+var weakPasswords = map[string]bool{
+	"":           true,
+	"password":   true,
+	"testonly":   true,
+	"12345678":   true,
+	"123456789":  true,
+	"qwertyuiop": true,
+}
+
+var (
+	// errWeakPassword when the keystore password is too weak to protect a key
+	errWeakPassword = fmt.Errorf("password is too weak, it must have at least %d characters and not be a well known password (use --%s to allow it)", minPasswordLength, config.FlagInsecure)
+	// errPasswordMismatch when the password confirmation does not match the password
+	errPasswordMismatch = errors.New("passwords do not match")
+	// errNoTerminal when a password needs to be prompted but the input is not a terminal
+	errNoTerminal = fmt.Errorf("input is not a terminal, use --%s to provide the password", config.FlagPasswordFile)
+)
+
+var keystoreDirFlag = cli.StringFlag{
+	Name:  config.FlagKeystoreDir,
+	Usage: "Keystore `DIR` holding the key files",
+	Value: "./wallets",
+}
+
+var passwordFileFlag = cli.StringFlag{
+	Name:    config.FlagPasswordFile,
+	Aliases: []string{"p"},
+	Usage:   "Read the keystore password from `FILE` instead of prompting for it",
+}
+
+var newPasswordFileFlag = cli.StringFlag{
+	Name:  config.FlagNewPasswordFile,
+	Usage: "Read the new keystore password from `FILE` instead of prompting for it",
+}
+
+var insecureFlag = cli.BoolFlag{
+	Name:  config.FlagInsecure,
+	Usage: "Allow weak keystore passwords, only meant for testing",
+}
+
+var lightKDFFlag = cli.BoolFlag{
+	Name:  config.FlagLightKDF,
+	Usage: "Use a lighter key derivation, faster but weaker against brute force attacks",
+}
+
+// keystoreCommand is the command group managing the eth keystore files
+var keystoreCommand = &cli.Command{
+	Name:  "keystore",
+	Usage: "Manage eth keystore files",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "new",
+			Usage:  "Create a new key in the keystore",
+			Action: newKeystoreKey,
+			Flags:  []cli.Flag{&keystoreDirFlag, &passwordFileFlag, &insecureFlag, &lightKDFFlag},
+		},
+		{
+			Name:      "import",
+			Usage:     "Import a hex encoded private key from a file into the keystore",
+			ArgsUsage: "<keyfile>",
+			Action:    importKeystoreKey,
+			Flags:     []cli.Flag{&keystoreDirFlag, &passwordFileFlag, &insecureFlag, &lightKDFFlag},
+		},
+		{
+			Name:   "list",
+			Usage:  "List the keys in the keystore",
+			Action: listKeystoreKeys,
+			Flags:  []cli.Flag{&keystoreDirFlag},
+		},
+		{
+			Name:      "inspect",
+			Usage:     "Print the address of a keystore file without decrypting it",
+			ArgsUsage: "<keystore file>",
+			Action:    inspectKeystoreFile,
+		},
+		{
+			Name:      "change-password",
+			Usage:     "Change the password of a key in the keystore",
+			ArgsUsage: "<address>",
+			Action:    changeKeystorePassword,
+			Flags:     []cli.Flag{&keystoreDirFlag, &passwordFileFlag, &newPasswordFileFlag, &insecureFlag, &lightKDFFlag},
+		},
+	},
+}
+
+func newKeystoreKey(cliCtx *cli.Context) error {
+	password, err := readNewPassword(cliCtx, config.FlagPasswordFile, "Password: ")
+	if err != nil {
+		return err
+	}
+
+	account, err := openKeystore(cliCtx).NewAccount(password)
+	if err != nil {
+		return fmt.Errorf("failed to create key: %w", err)
+	}
+	fmt.Printf("Generated account with address: %v\n", account.Address.Hex())
+	fmt.Printf("Keystore file: %v\n", account.URL.Path)
+	return nil
+}
+
+func importKeystoreKey(cliCtx *cli.Context) error {
+	if cliCtx.NArg() != 1 {
+		return errors.New("the file holding the hex encoded private key is required")
+	}
+	key, err := crypto.LoadECDSA(cliCtx.Args().First())
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to load private key: %w", err)
 	}
 
-	fmt.Println("Generated account with address: ", account.Address.Hex())
+	password, err := readNewPassword(cliCtx, config.FlagPasswordFile, "Password: ")
+	if err != nil {
+		return err
+	}
+
+	account, err := openKeystore(cliCtx).ImportECDSA(key, password)
+	if err != nil {
+		return fmt.Errorf("failed to import key: %w", err)
+	}
+	fmt.Printf("Imported account with address: %v\n", account.Address.Hex())
+	fmt.Printf("Keystore file: %v\n", account.URL.Path)
 	return nil
 }
+
+func listKeystoreKeys(cliCtx *cli.Context) error {
+	for i, account := range openKeystore(cliCtx).Accounts() {
+		fmt.Printf("Account #%d: %v %v\n", i, account.Address.Hex(), account.URL.Path)
+	}
+	return nil
+}
+
+func inspectKeystoreFile(cliCtx *cli.Context) error {
+	if cliCtx.NArg() != 1 {
+		return errors.New("the keystore file is required")
+	}
+	keyJSON, err := os.ReadFile(cliCtx.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	var key struct {
+		Address string `json:"address"`
+	}
+	err = json.Unmarshal(keyJSON, &key)
+	if err != nil {
+		return fmt.Errorf("failed to decode keystore file: %w", err)
+	}
+	if !common.IsHexAddress(key.Address) {
+		return fmt.Errorf("keystore file has an invalid address: %q", key.Address)
+	}
+	fmt.Printf("Address: %v\n", common.HexToAddress(key.Address).Hex())
+	return nil
+}
+
+func changeKeystorePassword(cliCtx *cli.Context) error {
+	if cliCtx.NArg() != 1 || !common.IsHexAddress(cliCtx.Args().First()) {
+		return errors.New("the address of the key is required")
+	}
+	ks := openKeystore(cliCtx)
+	account, err := ks.Find(accounts.Account{Address: common.HexToAddress(cliCtx.Args().First())})
+	if err != nil {
+		return fmt.Errorf("failed to find key: %w", err)
+	}
+
+	password, err := readPassword(cliCtx, config.FlagPasswordFile, "Current password: ")
+	if err != nil {
+		return err
+	}
+	newPassword, err := readNewPassword(cliCtx, config.FlagNewPasswordFile, "New password: ")
+	if err != nil {
+		return err
+	}
+
+	err = ks.Update(account, password, newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to change password: %w", err)
+	}
+	fmt.Printf("Changed password of account with address: %v\n", account.Address.Hex())
+	return nil
+}
+
+// openKeystore opens the keystore of the directory set in the command flags
+func openKeystore(cliCtx *cli.Context) *keystore.KeyStore {
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if cliCtx.Bool(config.FlagLightKDF) {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+	return keystore.NewKeyStore(cliCtx.String(config.FlagKeystoreDir), scryptN, scryptP)
+}
+
+// readPassword reads the password from the file set in the flag, or prompts for it
+func readPassword(cliCtx *cli.Context, fileFlag, prompt string) (string, error) {
+	if path := cliCtx.String(fileFlag); path != "" {
+		return readPasswordFile(path)
+	}
+	return promptPassword(prompt)
+}
+
+// readNewPassword reads the password to encrypt a key with, asking to confirm it when prompted.
+// Weak passwords are refused unless the insecure flag is set.
+func readNewPassword(cliCtx *cli.Context, fileFlag, prompt string) (string, error) {
+	var password string
+	if path := cliCtx.String(fileFlag); path != "" {
+		var err error
+		password, err = readPasswordFile(path)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		var err error
+		password, err = promptPassword(prompt)
+		if err != nil {
+			return "", err
+		}
+		confirmation, err := promptPassword("Repeat password: ")
+		if err != nil {
+			return "", err
+		}
+		if password != confirmation {
+			return "", errPasswordMismatch
+		}
+	}
+
+	if !cliCtx.Bool(config.FlagInsecure) && isWeakPassword(password) {
+		return "", errWeakPassword
+	}
+	return password, nil
+}
+
+// readPasswordFile reads the password from the first line of the file
+func readPasswordFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open password file: %w", err)
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read password file: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// promptPassword prompts for the password in the terminal without echoing it
+func promptPassword(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errNoTerminal
+	}
+	fmt.Print(prompt)
+	password, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(password), nil
+}
+
+func isWeakPassword(password string) bool {
+	return len(password) < minPasswordLength || weakPasswords[strings.ToLower(password)]
+}
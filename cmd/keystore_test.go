@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func runKeystoreCommand(t *testing.T, args ...string) error {
+	t.Helper()
+	app := cli.NewApp()
+	app.Commands = []*cli.Command{keystoreCommand}
+	return app.Run(append([]string{appName, "keystore"}, args...))
+}
+
+func writeTestingFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600)) //nolint:gomnd
+	return path
+}
+
+func TestKeystoreCommands(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := writeTestingFile(t, "password", "correct horse battery\n")
+	newPasswordFile := writeTestingFile(t, "new-password", "staple battery horse\n")
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	keyFile := writeTestingFile(t, "key", common.Bytes2Hex(crypto.FromECDSA(key)))
+
+	require.NoError(t, runKeystoreCommand(t, "new", "--dir", dir, "--password-file", passwordFile, "--lightkdf"))
+	require.NoError(t, runKeystoreCommand(t, "import", "--dir", dir, "--password-file", passwordFile, "--lightkdf", keyFile))
+	require.NoError(t, runKeystoreCommand(t, "list", "--dir", dir))
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	require.Len(t, ks.Accounts(), 2)
+	account, err := ks.Find(accounts.Account{Address: address})
+	require.NoError(t, err)
+	require.NoError(t, runKeystoreCommand(t, "inspect", account.URL.Path))
+
+	require.NoError(t, runKeystoreCommand(t, "change-password", "--dir", dir, "--password-file", passwordFile, "--new-password-file", newPasswordFile, "--lightkdf", address.Hex()))
+	keyJSON, err := os.ReadFile(account.URL.Path)
+	require.NoError(t, err)
+	_, err = keystore.DecryptKey(keyJSON, "correct horse battery")
+	require.Error(t, err)
+	decrypted, err := keystore.DecryptKey(keyJSON, "staple battery horse")
+	require.NoError(t, err)
+	assert.Equal(t, key.D, decrypted.PrivateKey.D)
+
+	// The old password does not unlock the key anymore
+	err = runKeystoreCommand(t, "change-password", "--dir", dir, "--password-file", passwordFile, "--new-password-file", newPasswordFile, address.Hex())
+	require.Error(t, err)
+}
+
+func TestKeystoreWeakPasswords(t *testing.T) {
+	dir := t.TempDir()
+	for _, password := range []string{"", "password", "Password", "short"} {
+		passwordFile := writeTestingFile(t, "password", password)
+		err := runKeystoreCommand(t, "new", "--dir", dir, "--password-file", passwordFile, "--lightkdf")
+		require.ErrorIs(t, err, errWeakPassword)
+	}
+
+	passwordFile := writeTestingFile(t, "password", "password")
+	require.NoError(t, runKeystoreCommand(t, "new", "--dir", dir, "--password-file", passwordFile, "--lightkdf", "--insecure"))
+}
+
+func TestKeystoreInspectInvalidFile(t *testing.T) {
+	require.Error(t, runKeystoreCommand(t, "inspect", writeTestingFile(t, "key.json", `{"address":"nope"}`)))
+	require.Error(t, runKeystoreCommand(t, "inspect"))
+}
@@ -17,6 +17,10 @@ const (
 	ETHTXMANAGER = "eth-tx-manager"
 	// SEQUENCE_SENDER is the sequence sender component identifier
 	SEQUENCE_SENDER = "sequence-sender"
+	// ADMIN_API is the sequence sender admin API component identifier
+	ADMIN_API = "admin-api"
+	// FORCED_BATCH_WATCHER is the forced batch watcher component identifier
+	FORCED_BATCH_WATCHER = "forced-batch-watcher"
 )
 
 const (
@@ -47,14 +51,6 @@ var toFlag = cli.StringFlag{
 	Value:    "",
 }
 
-var passwordFlag = cli.StringFlag{
-	Name:     config.FlagPassword,
-	Aliases:  []string{"p"},
-	Usage:    "set keystore password",
-	Required: false,
-	Value:    "password",
-}
-
 func main() {
 	app := cli.NewApp()
 	app.Name = appName
@@ -62,7 +58,6 @@ func main() {
 		&configFileFlag,
 		&networkJsonFlag,
 		&toFlag,
-		&passwordFlag,
 	}
 	app.Commands = []*cli.Command{
 		{
@@ -72,13 +67,9 @@ func main() {
 			Action:  start,
 			Flags:   flags,
 		},
-		{
-			Name:    "create-keystore",
-			Aliases: []string{},
-			Usage:   "Create new eth keystore",
-			Action:  createKeystore,
-			Flags:   flags,
-		},
+		keystoreCommand,
+		decodeDACommand,
+		daProtocolCommand,
 	}
 
 	err := app.Run(os.Args)
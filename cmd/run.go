@@ -2,19 +2,21 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"os"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/sieniven/zkevm-nubit/config"
 	"github.com/sieniven/zkevm-nubit/dataavailability"
+	"github.com/sieniven/zkevm-nubit/dataavailability/ethblob"
 	"github.com/sieniven/zkevm-nubit/dataavailability/nubit"
 	"github.com/sieniven/zkevm-nubit/etherman"
 	"github.com/sieniven/zkevm-nubit/ethtxmanager"
 	"github.com/sieniven/zkevm-nubit/log"
 	"github.com/sieniven/zkevm-nubit/sequencesender"
+	"github.com/sieniven/zkevm-nubit/signer"
 	"github.com/urfave/cli/v2"
 )
 
@@ -25,67 +27,93 @@ func start(cliCtx *cli.Context) error {
 	}
 	setupLog(c.Log)
 
+	// The supervisor stops the node on SIGINT and SIGTERM
+	sup := newSupervisor(cliCtx.Context, c.ShutdownTimeout.Duration)
+
 	// Initialize ether manager instance
 	etherMan, err := newEtherman(*c)
 	if err != nil {
 		panic(err)
 	}
 
-	// Create new data avaiability backend and set sequencer flag to false
-	isSequencer := false
-	_, pk, err := etherMan.LoadAuthFromKeyStore(c.SequenceSender.DAPermitApiPrivateKey.Path, c.SequenceSender.DAPermitApiPrivateKey.Password)
+	// Initialize eth tx manager instance
+	etm, err := ethtxmanager.New(c.EthTxManager, etherMan)
 	if err != nil {
 		return err
 	}
+	// The monitored txs storage is closed once every component is stopped, including the eth
+	// tx manager itself if it outlived the shutdown timeout
+	defer etm.Stop()
 
-	log.Infof("from pk %s", crypto.PubkeyToAddress(pk.PublicKey))
-	daBackend, err := nubit.NewNubitDABackend(&c.DataAvailability, pk)
+	// Create new data avaiability manager
+	da, err := newDataAvailability(sup.ctx, *c, etherMan, etm)
 	if err != nil {
 		return err
 	}
+	etherMan.SetDataProvider(da)
+
+	// Initialize mock sequence sender
+	seqSender := createMockSequenceSender(*c, etm, etherMan, da)
 
-	da, err := dataavailability.New(isSequencer, daBackend)
+	// Resume after the last sequenced batch
+	err = seqSender.RestoreLastSequencedBatch(sup.ctx)
 	if err != nil {
 		return err
 	}
-	etherMan.SetDataProvider(da)
-
-	// Initialize eth tx manager instance
-	etm := ethtxmanager.New(c.EthTxManager, etherMan)
 
-	// Initialize mock sequence sender
-	seqSender := createMockSequenceSender(*c, etm, etherMan)
+	// Start eth tx manager, the components are stopped in the reverse order they are added so
+	// the sequence being sent on shutdown is added to the eth tx manager before it stops
+	sup.add(ETHTXMANAGER, func(ctx context.Context) error {
+		etm.Start(ctx)
+		return nil
+	})
+
+	// Start forced batch watcher, the forced batches are then sequenced by the sequence sender
+	if c.SequenceSender.ForcedBatches.Enabled {
+		watcher := sequencesender.NewForcedBatchWatcher(c.SequenceSender.ForcedBatches, etherMan, c.SequenceSender.L1SyncFromBlock)
+		seqSender.SetForcedBatchWatcher(watcher)
+		sup.add(FORCED_BATCH_WATCHER, func(ctx context.Context) error {
+			watcher.Start(ctx)
+			return nil
+		})
+	}
 
 	// Start mock sequence sender
-	go seqSender.Start(cliCtx.Context)
+	sup.add(SEQUENCE_SENDER, seqSender.Start)
+
+	// Start sequence sender admin API
+	if c.SequenceSender.Admin.Enabled {
+		sup.add(ADMIN_API, func(ctx context.Context) error {
+			_, err := seqSender.StartAdminServer(ctx)
+			if err != nil {
+				return err
+			}
+			<-ctx.Done()
+			return nil
+		})
+	}
 
-	// Start send sequence flag handler
-	reader := bufio.NewReader(os.Stdin)
-	seqSender.SendSequenceHandle(cliCtx.Context, reader)
-	return nil
+	// Start send sequence flag handler, reading the standard input can not be interrupted so it
+	// is not waited for on shutdown
+	if c.SequenceSender.StdinHandlerEnabled {
+		reader := bufio.NewReader(os.Stdin)
+		go seqSender.SendSequenceHandle(sup.ctx, reader)
+	}
+
+	return sup.run()
 }
 
 // createMockSequenceSender is the mock function for PolygonCDK node that
 // creates a new instance of the mock sequence sender for the mock node.
-func createMockSequenceSender(cfg config.Config, etm *ethtxmanager.Client, etherMan *etherman.Client) *sequencesender.SequenceSender {
-	// Create new data avaiability manager
-	da, err := newDataAvailability(cfg, etherMan)
-	if err != nil {
-		panic(err)
-	}
-
-	etherMan.SetDataProvider(da)
-	_, privKey, err := etherMan.LoadAuthFromKeyStore(cfg.Key.Path, cfg.Key.Password)
+func createMockSequenceSender(cfg config.Config, etm *ethtxmanager.Client, etherMan *etherman.Client, da *dataavailability.DataAvailability) *sequencesender.SequenceSender {
+	l1Signer, err := etherMan.LoadSigner(cfg.Signer, cfg.Key)
 	if err != nil {
 		panic(err)
 	}
 	if cfg.SequenceSender.SenderAddress.Cmp(common.Address{}) == 0 {
 		panic(errors.New("sequence sender address not found"))
 	}
-	if privKey == nil { //nolint:staticcheck
-		panic(errors.New("private key not found"))
-	}
-	fmt.Printf("from pk %s, from sender %s\n", crypto.PubkeyToAddress(privKey.PublicKey), cfg.SequenceSender.SenderAddress.String()) //nolint:staticcheck
+	fmt.Printf("from signer %s, from sender %s\n", l1Signer.Address(), cfg.SequenceSender.SenderAddress.String())
 
 	// Initialize new sequence sender instance
 	seqSender, err := sequencesender.New(cfg.SequenceSender, etherMan, etm)
@@ -100,20 +128,44 @@ func newEtherman(c config.Config) (*etherman.Client, error) {
 	return etherman.NewClient(c.Etherman, c.L1Config)
 }
 
-func newDataAvailability(c config.Config, etherMan *etherman.Client) (*dataavailability.DataAvailability, error) {
+func newDataAvailability(ctx context.Context, c config.Config, etherMan *etherman.Client, etm *ethtxmanager.Client) (*dataavailability.DataAvailability, error) {
 	isSequencer := false
-	_, pk, err := etherMan.LoadAuthFromKeyStore(c.SequenceSender.DAPermitApiPrivateKey.Path, c.SequenceSender.DAPermitApiPrivateKey.Password)
-	if err != nil {
-		return nil, err
-	}
 
-	log.Infof("from pk %s", crypto.PubkeyToAddress(pk.PublicKey))
-	daBackend, err := nubit.NewNubitDABackend(&c.DataAvailability, pk)
-	if err != nil {
-		return nil, err
+	var daBackend dataavailability.DABackender
+	switch c.DABackendType {
+	case dataavailability.Nubit:
+		// the JSON-RPC signers do not sign the raw sequence hashes
+		if c.SequenceSender.DASigner.Type == signer.TypeJSONRPC {
+			return nil, fmt.Errorf("the %s signer can not sign the sequences of the %s backend", c.SequenceSender.DASigner.Type, c.DABackendType)
+		}
+		daSigner, err := etherMan.LoadSigner(c.SequenceSender.DASigner, c.SequenceSender.DAPermitApiPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("from signer %s", daSigner.Address())
+		daBackend, err = nubit.NewNubitDABackend(&c.DataAvailability, daSigner)
+		if err != nil {
+			return nil, err
+		}
+	case dataavailability.EthereumBlobs:
+		// the JSON-RPC signers do not sign blob txs
+		if c.Signer.Type == signer.TypeJSONRPC {
+			return nil, fmt.Errorf("the %s signer can not sign the blob txs of the %s backend", c.Signer.Type, c.DABackendType)
+		}
+		store, err := ethblob.NewFileSidecarStore(c.BlobDataAvailability.BlobSidecarStoragePath)
+		if err != nil {
+			return nil, err
+		}
+		daBackend, err = ethblob.NewEthBlobDABackend(&c.BlobDataAvailability, etm, store)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unexpected / unsupported DA protocol: %s", c.DABackendType)
 	}
 
-	return dataavailability.New(isSequencer, daBackend)
+	return dataavailability.New(ctx, isSequencer, daBackend)
 }
 
 func setupLog(c log.Config) {
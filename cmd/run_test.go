@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sieniven/zkevm-nubit/config"
+	"github.com/sieniven/zkevm-nubit/dataavailability"
+	"github.com/sieniven/zkevm-nubit/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthBlobsRejectJSONRPCSigner(t *testing.T) {
+	c, err := config.Default()
+	require.NoError(t, err)
+	c.DABackendType = dataavailability.EthereumBlobs
+	c.Signer.Type = signer.TypeJSONRPC
+
+	_, err = newDataAvailability(context.Background(), *c, nil, nil)
+	assert.ErrorContains(t, err, "can not sign the blob txs")
+}
+
+func TestNubitRejectJSONRPCDASigner(t *testing.T) {
+	c, err := config.Default()
+	require.NoError(t, err)
+	c.DABackendType = dataavailability.Nubit
+	c.SequenceSender.DASigner.Type = signer.TypeJSONRPC
+
+	_, err = newDataAvailability(context.Background(), *c, nil, nil)
+	assert.ErrorContains(t, err, "can not sign the sequences")
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sieniven/zkevm-nubit/log"
+)
+
+// errShutdownTimeout when the components do not stop within the shutdown timeout
+var errShutdownTimeout = errors.New("components did not stop within the shutdown timeout")
+
+// component is a long running part of the node, it runs until its context is done
+type component struct {
+	name string
+	run  func(ctx context.Context) error
+	// cancel stops the component, once the components added after it returned
+	cancel context.CancelFunc
+}
+
+// componentExit is the result of a component that stopped running
+type componentExit struct {
+	name string
+	err  error
+}
+
+// supervisor runs the components of the node until it is signaled to stop with SIGINT or SIGTERM,
+// or until any of them stops. Then it stops the components in the reverse order they were added,
+// canceling the context of each one once the ones added after it drained their in-flight work,
+// for up to the shutdown timeout.
+type supervisor struct {
+	// ctx is done once the node is stopping, before the components are stopped one by one
+	ctx             context.Context
+	cancel          context.CancelFunc
+	stopSignals     context.CancelFunc
+	shutdownTimeout time.Duration
+	components      []component
+}
+
+// newSupervisor creates a supervisor listening for the stop signals
+func newSupervisor(parent context.Context, shutdownTimeout time.Duration) *supervisor {
+	signalCtx, stopSignals := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := context.WithCancel(signalCtx)
+	return &supervisor{
+		ctx:             ctx,
+		cancel:          cancel,
+		stopSignals:     stopSignals,
+		shutdownTimeout: shutdownTimeout,
+	}
+}
+
+// add adds a component to be run by the supervisor, it is stopped after the components added
+// after it, so it is added before the components depending on it
+func (s *supervisor) add(name string, run func(ctx context.Context) error) {
+	s.components = append(s.components, component{name: name, run: run})
+}
+
+// run starts the components and blocks until they stop, or until the shutdown timeout elapses
+// once the node is stopping. It returns the errors of the components.
+func (s *supervisor) run() error {
+	defer s.stopSignals()
+	defer s.cancel()
+
+	exits := make(chan componentExit, len(s.components))
+	running := map[string]bool{}
+	for i := range s.components {
+		c := &s.components[i]
+		// the context of the component is not done with the supervisor one, but once it is its
+		// turn to stop
+		ctx, cancel := context.WithCancel(context.WithoutCancel(s.ctx))
+		defer cancel()
+		c.cancel = cancel
+		running[c.name] = true
+		go func(ctx context.Context, c component) {
+			exits <- componentExit{name: c.name, err: c.run(ctx)}
+		}(ctx, *c)
+	}
+
+	var errs []error
+	handleExit := func(exit componentExit) {
+		delete(running, exit.name)
+		if exit.err != nil {
+			log.Errorf("%s stopped: %v", exit.name, exit.err)
+			errs = append(errs, fmt.Errorf("%s: %w", exit.name, exit.err))
+		} else {
+			log.Infof("%s stopped", exit.name)
+		}
+	}
+
+	// Run until signaled to stop or until any component stops, which stops the node
+	select {
+	case <-s.ctx.Done():
+		log.Info("stopping node")
+	case exit := <-exits:
+		handleExit(exit)
+		log.Infof("stopping node as %s stopped", exit.name)
+	}
+	s.cancel()
+
+	timeout := time.NewTimer(s.shutdownTimeout)
+	defer timeout.Stop()
+	for i := len(s.components) - 1; i >= 0; i-- {
+		c := s.components[i]
+		c.cancel()
+		for running[c.name] {
+			select {
+			case exit := <-exits:
+				handleExit(exit)
+			case <-timeout.C:
+				var names []string
+				for _, c := range s.components {
+					if running[c.name] {
+						names = append(names, c.name)
+					}
+				}
+				errs = append(errs, fmt.Errorf("%w: %s", errShutdownTimeout, strings.Join(names, ", ")))
+				return errors.Join(errs...)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runSupervisor runs the supervisor in the background and returns the channel of its result
+func runSupervisor(sup *supervisor) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- sup.run()
+	}()
+	return result
+}
+
+func waitSupervisor(t *testing.T, result <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "supervisor did not stop")
+		return nil
+	}
+}
+
+func TestSupervisorStopsOnSignal(t *testing.T) {
+	sup := newSupervisor(context.Background(), time.Second)
+	drained := make(chan string, 2)
+	for _, name := range []string{"first", "second"} {
+		name := name
+		sup.add(name, func(ctx context.Context) error {
+			<-ctx.Done()
+			drained <- name
+			return nil
+		})
+	}
+	result := runSupervisor(sup)
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+	require.NoError(t, waitSupervisor(t, result))
+	assert.ElementsMatch(t, []string{"first", "second"}, []string{<-drained, <-drained})
+}
+
+func TestSupervisorStopsWhenComponentStops(t *testing.T) {
+	sup := newSupervisor(context.Background(), time.Second)
+	failure := errors.New("connection lost")
+	sup.add("failing", func(ctx context.Context) error {
+		return failure
+	})
+	sup.add("running", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	err := waitSupervisor(t, runSupervisor(sup))
+	assert.ErrorIs(t, err, failure)
+	assert.ErrorContains(t, err, "failing")
+}
+
+func TestSupervisorShutdownTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sup := newSupervisor(ctx, 10*time.Millisecond)
+	stuck := make(chan struct{})
+	defer close(stuck)
+	sup.add("stuck", func(ctx context.Context) error {
+		<-stuck
+		return nil
+	})
+	result := runSupervisor(sup)
+
+	cancel()
+	err := waitSupervisor(t, result)
+	assert.ErrorIs(t, err, errShutdownTimeout)
+	assert.ErrorContains(t, err, "stuck")
+}
+
+func TestSupervisorStopsComponentsInReverseOrder(t *testing.T) {
+	sup := newSupervisor(context.Background(), time.Second)
+	stopped := make(chan string, 3)
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		sup.add(name, func(ctx context.Context) error {
+			<-ctx.Done()
+			// the components added before are still running while draining
+			time.Sleep(10 * time.Millisecond)
+			stopped <- name
+			return nil
+		})
+	}
+	result := runSupervisor(sup)
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+	require.NoError(t, waitSupervisor(t, result))
+	assert.Equal(t, []string{"third", "second", "first"}, []string{<-stopped, <-stopped, <-stopped})
+}
@@ -8,23 +8,41 @@ import (
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/sieniven/zkevm-nubit/dataavailability"
+	"github.com/sieniven/zkevm-nubit/dataavailability/ethblob"
 	"github.com/sieniven/zkevm-nubit/dataavailability/nubit"
 
 	"github.com/sieniven/zkevm-nubit/etherman"
 	"github.com/sieniven/zkevm-nubit/ethtxmanager"
 	"github.com/sieniven/zkevm-nubit/log"
 	"github.com/sieniven/zkevm-nubit/sequencesender"
+	"github.com/sieniven/zkevm-nubit/signer"
 	"github.com/spf13/viper"
 	"github.com/urfave/cli/v2"
 )
 
 const (
-	FlagCfg       = "cfg"
-	FlagNetwork   = "network"
-	FlagRequestID = "requestid"
-	FlagTo        = "to"
-	FlagPassword  = "password"
-	FlagAdmin     = "admin"
+	FlagCfg             = "cfg"
+	FlagNetwork         = "network"
+	FlagRequestID       = "requestid"
+	FlagTo              = "to"
+	FlagAdmin           = "admin"
+	FlagKeystoreDir     = "dir"
+	FlagPasswordFile    = "password-file"
+	FlagNewPasswordFile = "new-password-file"
+	FlagInsecure        = "insecure"
+	FlagLightKDF        = "lightkdf"
+	FlagTxHash          = "tx"
+	FlagCalldata        = "calldata"
+	FlagLastBatch       = "last-batch"
+	FlagFetch           = "fetch"
+	FlagDAAddress       = "address"
+	FlagDeployNubit     = "deploy-nubit"
+	FlagSequencer       = "sequencer"
+	FlagAllowed         = "allowed"
+	FlagFrom            = "from"
+	FlagDryRun          = "dry-run"
+	FlagTimeout         = "timeout"
 )
 
 // Represents the configuration of the entire mock Polygon CDK Node
@@ -39,8 +57,18 @@ type Config struct {
 	SequenceSender   sequencesender.Config
 	L1Config         etherman.L1Config
 	Key              types.KeystoreFileConfig
+	Signer           signer.Config
 	DataAvailability nubit.Config
 	Log              log.Config
+
+	// DABackendType selects the data availability backend the sequences are posted to
+	DABackendType dataavailability.DABackendType `mapstructure:"DABackendType"`
+	// BlobDataAvailability is the Ethereum blobs data availability backend configuration
+	BlobDataAvailability ethblob.Config
+
+	// ShutdownTimeout is the time given to the components to drain their in-flight work when
+	// the node is stopping, e.g. the sequence being posted to the DA layer
+	ShutdownTimeout types.Duration `mapstructure:"ShutdownTimeout"`
 }
 
 // Default parses the default configuration values
@@ -2,6 +2,9 @@ package config
 
 // DefaultValues is the default configuration
 const DefaultValues = `
+DABackendType = "Nubit"
+ShutdownTimeout = "30s"
+
 [Log]
 Environment = "development" # "production" or "development"
 Level = "info"
@@ -9,6 +12,8 @@ Outputs = ["stderr"]
 
 [Etherman]
 URL = "http://localhost:8545"
+	[Etherman.GasPrice]
+	Aggregation = "max"
 
 [EthTxManager]
 FrequencyToMonitorTxs = "1s"
@@ -16,14 +21,55 @@ WaitTxToBeMined = "2m"
 ForcedGas = 0
 GasPriceMarginFactor = 1
 MaxGasPriceLimit = 0
+MaxHistorySize = 0
+MaxMonitoredTxAge = "0s"
+StorageType = "memory"
+StoragePath = ""
+	[EthTxManager.DB]
+	Name = "state_db"
+	User = "state_user"
+	Password = "state_password"
+	Host = "localhost"
+	Port = "5432"
+	EnableLog = false
+	MaxConns = 10
 
 [SequenceSender]
 WaitPeriodSendSequence = "5s"
 MaxTxSizeForL1 = 131072
 L2Coinbase = "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
 GasOffset = 80000
+MaxPendingSequences = 1
+DAMessageMaxAge = "10m"
 MaxBatchesForL1 = 10
 DAPermitApiPrivateKey = {Path = "/pk/sequencer.keystore", Password = "testonly"}
+DASigner = {Type = "keystore"}
+StdinHandlerEnabled = true
+FirstBatchNumber = 0
+LastBatchStoragePath = "./sequencesender/last_batch.json"
+ReconcileWithL1 = true
+L1SyncFromBlock = 0
+	[SequenceSender.BatchSource]
+	Type = "synthetic"
+	URL = ""
+	Timeout = "10s"
+	Path = ""
+	[SequenceSender.Triggers]
+	MinPendingBatches = 0
+	MinPendingBytes = 0
+	MaxPendingBatchAge = "0s"
+	MaxL1GasPrice = 0
+	[SequenceSender.Admin]
+	Enabled = false
+	Host = "127.0.0.1"
+	Port = 8124
+	[SequenceSender.ForcedBatches]
+	Enabled = false
+	PollInterval = "5s"
+	Inject = true
+
+[Signer]
+Type = "keystore"
 
 [DataAvailability]
 NubitRpcURL = "http://127.0.0.1:26658"
@@ -31,4 +77,10 @@ NubitAuthKey = ""
 NubitNamespace = "xlayer"
 NubitGetProofMaxRetry = "10"
 NubitGetProofWaitPeriod = "5s"
+
+[BlobDataAvailability]
+BlobSidecarStoragePath = "./blobs"
+BlobGasOffset = 0
+BlobConfirmationMaxRetry = 30
+BlobConfirmationWaitPeriod = "12s"
 `
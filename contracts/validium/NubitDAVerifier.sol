@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: AGPL-3.0
+pragma solidity 0.8.20;
+
+import "../../interfaces/IDataAvailabilityProtocol.sol";
+
+/**
+ * Data availability protocol of a validium posting its sequences to Nubit DA.
+ * The data availability message is the Nubit DA blob ID of the sequence, along with the accumulated
+ * hash of the batches transactions hashes signed by the sequencer.
+ * The sequence data can then be retrieved from Nubit DA with the blob ID.
+ */
+contract NubitDAVerifier is IDataAvailabilityProtocol {
+    /**
+     * @notice Struct of the data availability message
+     * @param blobID Nubit DA blob ID of the sequence data
+     * @param signature Hash signed by the sequencer followed by the signature: hash (32) || r (32) || s (32) || v (1)
+     */
+    struct BlobData {
+        bytes blobID;
+        bytes signature;
+    }
+
+    // Name of the data availability protocol
+    string internal constant _PROTOCOL_NAME = "NubitDA";
+
+    // Size of the signature field of the blob data
+    uint256 internal constant _SIGNATURE_SIZE = 97;
+
+    // Owner address, able to update the sequencer
+    address public owner;
+
+    // Sequencer address, signing the data availability messages
+    address public sequencer;
+
+    /**
+     * @dev Emitted when the owner updates the sequencer
+     */
+    event SetSequencer(address newSequencer);
+
+    /**
+     * @dev Thrown when the caller is not the owner
+     */
+    error OnlyOwner();
+
+    /**
+     * @dev Thrown when the signature field of the blob data is not 97 bytes long
+     */
+    error InvalidSignatureLength();
+
+    /**
+     * @dev Thrown when the signed hash does not match the accumulated transactions hash of the sequence
+     */
+    error UnexpectedHash();
+
+    /**
+     * @dev Thrown when the hash is not signed by the sequencer
+     */
+    error InvalidSignature();
+
+    /**
+     * @param _sequencer Sequencer address
+     */
+    constructor(address _sequencer) {
+        owner = msg.sender;
+        sequencer = _sequencer;
+    }
+
+    /**
+     * @notice Allows the owner to update the sequencer signing the data availability messages
+     * @param newSequencer New sequencer address
+     */
+    function setSequencer(address newSequencer) external {
+        if (msg.sender != owner) {
+            revert OnlyOwner();
+        }
+
+        sequencer = newSequencer;
+
+        emit SetSequencer(newSequencer);
+    }
+
+    /**
+     * @notice Verifies that the sequence is signed by the sequencer
+     * @param hash Accumulated hash of the batches transactions hashes of the sequence
+     * @param dataAvailabilityMessage ABI encoded BlobData
+     */
+    function verifyMessage(
+        bytes32 hash,
+        bytes calldata dataAvailabilityMessage
+    ) external view {
+        BlobData memory blobData = abi.decode(
+            dataAvailabilityMessage,
+            (BlobData)
+        );
+
+        bytes memory signature = blobData.signature;
+        if (signature.length != _SIGNATURE_SIZE) {
+            revert InvalidSignatureLength();
+        }
+
+        bytes32 signedHash;
+        bytes32 r;
+        bytes32 s;
+        uint8 v;
+        assembly {
+            signedHash := mload(add(signature, 32))
+            r := mload(add(signature, 64))
+            s := mload(add(signature, 96))
+            v := byte(0, mload(add(signature, 128)))
+        }
+
+        if (signedHash != hash) {
+            revert UnexpectedHash();
+        }
+
+        address signer = ecrecover(hash, v, r, s);
+        if (signer == address(0) || signer != sequencer) {
+            revert InvalidSignature();
+        }
+    }
+
+    /**
+     * @notice Return the protocol name
+     */
+    function getProcotolName() external pure override returns (string memory) {
+        return _PROTOCOL_NAME;
+    }
+}
@@ -6,5 +6,8 @@ type DABackendType string
 const (
 	// DataAvailabilityCommittee is the DAC protocol backend
 	DataAvailabilityCommittee DABackendType = "DataAvailabilityCommittee"
-	Nubit                     DABackendType = "Nubit"
+	// Nubit is the NubitDA protocol backend
+	Nubit DABackendType = "Nubit"
+	// EthereumBlobs is the Ethereum EIP-4844 blobs backend
+	EthereumBlobs DABackendType = "EthereumBlobs"
 )
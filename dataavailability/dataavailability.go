@@ -23,8 +23,10 @@ type DataAvailability struct {
 	ctx context.Context
 }
 
-// New creates a DataAvailability instance
+// New creates a DataAvailability instance, the data is retrieved from the backend until the
+// context is done
 func New(
+	ctx context.Context,
 	isTrustedSequencer bool,
 	backend DABackender,
 	// state stateInterface,
@@ -35,7 +37,7 @@ func New(
 		backend:            backend,
 		// state:              state,
 		// zkEVMClient: zkEVMClient,
-		ctx: context.Background(),
+		ctx: ctx,
 	}
 	err := da.backend.Init()
 	return da, err
@@ -1,7 +1,6 @@
 package datacommittee
 
 import (
-	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,6 +10,7 @@ import (
 
 	polygondatacommittee "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygondatacommittee_xlayer"
 	"github.com/sieniven/zkevm-nubit/log"
+	"github.com/sieniven/zkevm-nubit/signer"
 
 	"github.com/0xPolygon/cdk-data-availability/client"
 	daTypes "github.com/0xPolygon/cdk-data-availability/types"
@@ -39,7 +39,7 @@ type DataCommittee struct {
 // DataCommitteeBackend implements the DAC integration
 type DataCommitteeBackend struct {
 	dataCommitteeContract      *polygondatacommittee.PolygondatacommitteeXlayer
-	privKey                    *ecdsa.PrivateKey
+	signer                     signer.Signer
 	dataCommitteeClientFactory client.Factory
 
 	committeeMembers        []DataCommitteeMember
@@ -51,7 +51,7 @@ type DataCommitteeBackend struct {
 func New(
 	l1RPCURL string,
 	dataCommitteeAddr common.Address,
-	privKey *ecdsa.PrivateKey,
+	daSigner signer.Signer,
 	dataCommitteeClientFactory client.Factory,
 ) (*DataCommitteeBackend, error) {
 	ethClient, err := ethclient.Dial(l1RPCURL)
@@ -65,7 +65,7 @@ func New(
 	}
 	return &DataCommitteeBackend{
 		dataCommitteeContract:      dataCommittee,
-		privKey:                    privKey,
+		signer:                     daSigner,
 		dataCommitteeClientFactory: dataCommitteeClientFactory,
 		ctx:                        context.Background(),
 	}, nil
@@ -165,7 +165,7 @@ func (s *DataCommitteeBackend) PostSequence(ctx context.Context, batchesData [][
 	for _, seq := range batchesData {
 		sequence = append(sequence, seq)
 	}
-	signedSequence, err := sequence.Sign(s.privKey)
+	signedSequence, err := signer.SignSequence(ctx, s.signer, sequence)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,28 @@
+package ethblob
+
+const blobDataABI = `[
+	{
+		"type": "function",
+		"name": "BlobData",
+		"inputs": [
+			{
+			"name": "blobData",
+			"type": "tuple",
+			"internalType": "struct EthBlobDAVerifier.BlobData",
+			"components": [
+				{
+				"name": "txHash",
+				"type": "bytes32",
+				"internalType": "bytes32"
+				},
+				{
+				"name": "versionedHashes",
+				"type": "bytes32[]",
+				"internalType": "bytes32[]"
+				}
+			]
+			}
+		],
+		"stateMutability": "pure"
+	}
+]`
@@ -0,0 +1,250 @@
+package ethblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/sieniven/zkevm-nubit/dataavailability/nubit"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/sieniven/zkevm-nubit/log"
+)
+
+const (
+	ethTxManagerOwner        = "ethblob-da"
+	monitoredIDFormat        = "blobs-%s"
+	monitoredIDAttemptFormat = "blobs-%s-%d"
+)
+
+// EthBlobDABackend implements the DA integration with Ethereum EIP-4844 blobs
+type EthBlobDABackend struct {
+	config       *Config
+	ethTxManager ethTxManager
+	source       BlobSidecarSource
+}
+
+// NewEthBlobDABackend is the factory method to create a new instance of EthBlobDABackend.
+// If the sidecar source also implements BlobSidecarStorer, the posted blob sidecars are
+// stored into it.
+func NewEthBlobDABackend(
+	cfg *Config,
+	ethTxManager ethTxManager,
+	source BlobSidecarSource,
+) (*EthBlobDABackend, error) {
+	log.Infof("EthBlobDABackend config: %#v", cfg)
+	if source == nil {
+		return nil, fmt.Errorf("blob sidecar source not provided")
+	}
+	if cfg.BlobSenderAddress == (common.Address{}) {
+		return nil, fmt.Errorf("blob sender address not provided")
+	}
+
+	return &EthBlobDABackend{
+		config:       cfg,
+		ethTxManager: ethTxManager,
+		source:       source,
+	}, nil
+}
+
+// Init initializes the Ethereum blobs backend
+func (backend *EthBlobDABackend) Init() error {
+	return nil
+}
+
+// PostSequence sends the sequence data to L1 as a blob tx through the eth tx manager, and
+// returns the dataAvailabilityMessage as expected by the contract
+func (backend *EthBlobDABackend) PostSequence(ctx context.Context, batchesData [][]byte) ([]byte, error) {
+	// Pack the encoded sequence into blobs
+	data := nubit.EncodeSequence(batchesData)
+	blobs, err := EncodeBlobs(data)
+	if err != nil {
+		log.Errorf("Failed to encode sequence into blobs: %v", err)
+		return nil, err
+	}
+	sidecar, err := NewBlobTxSidecar(blobs)
+	if err != nil {
+		log.Errorf("Failed to build blob tx sidecar: %v", err)
+		return nil, err
+	}
+	versionedHashes := sidecar.BlobHashes()
+
+	// Keep a copy of the blobs, they are pruned from the L1 nodes after the retention period
+	if storer, ok := backend.source.(BlobSidecarStorer); ok {
+		for i, vh := range versionedHashes {
+			err = storer.StoreBlobSidecar(ctx, vh, BlobSidecar{
+				Blob:       sidecar.Blobs[i],
+				Commitment: sidecar.Commitments[i],
+				Proof:      sidecar.Proofs[i],
+			})
+			if err != nil {
+				log.Errorf("Failed to store blob sidecar %s: %v", vh.String(), err)
+				return nil, err
+			}
+		}
+	}
+
+	// Add blob tx to be monitored, unless the same blobs were already posted
+	monitoredTxID, err := backend.addBlobTx(ctx, versionedHashes[0], sidecar)
+	if err != nil {
+		log.Errorf("Failed to add blob tx to eth tx manager: %v", err)
+		return nil, err
+	}
+	log.Infof("Data submitted to Ethereum blobs: %d bytes in %d blobs with monitored tx id %s", len(data), len(blobs), monitoredTxID)
+
+	// Wait for the blob tx to be confirmed on L1
+	txHash, err := backend.waitBlobTxConfirmed(ctx, monitoredTxID)
+	if err != nil {
+		log.Errorf("Blob tx %s not confirmed: %v", monitoredTxID, err)
+		return nil, err
+	}
+
+	blobData := BlobData{TxHash: txHash}
+	for _, vh := range versionedHashes {
+		blobData.VersionedHashes = append(blobData.VersionedHashes, vh)
+	}
+	return TryEncodeToDataAvailabilityMessage(blobData)
+}
+
+// addBlobTx adds the blob tx to the eth tx manager and returns its monitored tx id. The id is
+// derived from the first versioned hash and the number of previous attempts to post the same
+// blobs, so a pending or confirmed blob tx of a previous attempt is reused, while a new one is
+// added if all the previous ones failed
+func (backend *EthBlobDABackend) addBlobTx(ctx context.Context, versionedHash common.Hash, sidecar *types.BlobTxSidecar) (string, error) {
+	for attempt := 0; ; attempt++ {
+		monitoredTxID := fmt.Sprintf(monitoredIDFormat, versionedHash.Hex())
+		if attempt > 0 {
+			monitoredTxID = fmt.Sprintf(monitoredIDAttemptFormat, versionedHash.Hex(), attempt)
+		}
+
+		result, err := backend.ethTxManager.Result(ctx, ethTxManagerOwner, monitoredTxID)
+		if errors.Is(err, ethtxmanager.ErrNotFound) {
+			to := backend.config.BlobSenderAddress
+			err = backend.ethTxManager.AddBlob(ctx, ethTxManagerOwner, monitoredTxID, backend.config.BlobSenderAddress, &to, sidecar, backend.config.BlobGasOffset)
+			return monitoredTxID, err
+		} else if err != nil {
+			return "", err
+		}
+
+		if !blobTxFailed(result) {
+			log.Infof("Blob tx with monitored tx id %s already added with status %s", monitoredTxID, result.Status)
+			return monitoredTxID, nil
+		}
+	}
+}
+
+// waitBlobTxConfirmed polls the eth tx manager until the blob tx is confirmed, and returns
+// the hash of the tx that was mined successfully. Once the blob tx is confirmed or failed,
+// it is set as done in the eth tx manager
+func (backend *EthBlobDABackend) waitBlobTxConfirmed(ctx context.Context, monitoredTxID string) (common.Hash, error) {
+	waitPeriod := backend.config.BlobConfirmationWaitPeriod.Duration
+	if waitPeriod == 0 {
+		waitPeriod = DefaultConfirmationWaitPeriod
+	}
+
+	for tries := uint64(0); tries < backend.config.BlobConfirmationMaxRetry; tries++ {
+		result, err := backend.ethTxManager.Result(ctx, ethTxManagerOwner, monitoredTxID)
+		if err != nil {
+			log.Infof("Blob tx result not available: %s", err)
+		} else if blobTxFailed(result) {
+			backend.setBlobTxsDone(ctx)
+			return common.Hash{}, fmt.Errorf("blob tx %s", result.Status)
+		} else if txHash, ok := blobTxMined(result); ok {
+			backend.setBlobTxsDone(ctx)
+			return txHash, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return common.Hash{}, ctx.Err()
+		case <-time.After(waitPeriod):
+		}
+	}
+	return common.Hash{}, fmt.Errorf("blob tx not confirmed after %d retries", backend.config.BlobConfirmationMaxRetry)
+}
+
+// setBlobTxsDone sets the confirmed, failed and canceled blob txs as done, so the eth tx
+// manager stops looking into them
+func (backend *EthBlobDABackend) setBlobTxsDone(ctx context.Context) {
+	_, err := backend.ethTxManager.ProcessMonitoredTxs(ctx, ethTxManagerOwner, func(ethtxmanager.MonitoredTxResult) {})
+	if err != nil {
+		log.Warnf("Failed to set blob txs as done: %v", err)
+	}
+}
+
+// blobTxMined returns the hash of the tx of the monitored blob tx that was mined successfully
+func blobTxMined(result ethtxmanager.MonitoredTxResult) (common.Hash, bool) {
+	if result.Status != ethtxmanager.MonitoredTxStatusConfirmed && result.Status != ethtxmanager.MonitoredTxStatusDone {
+		return common.Hash{}, false
+	}
+	for txHash, txResult := range result.Txs {
+		if txResult.Receipt != nil && txResult.Receipt.Status == types.ReceiptStatusSuccessful {
+			return txHash, true
+		}
+	}
+	return common.Hash{}, false
+}
+
+// blobTxFailed returns if the monitored blob tx failed or was canceled, including when it was
+// already set as done without any tx mined successfully
+func blobTxFailed(result ethtxmanager.MonitoredTxResult) bool {
+	switch result.Status {
+	case ethtxmanager.MonitoredTxStatusFailed, ethtxmanager.MonitoredTxStatusCanceled:
+		return true
+	case ethtxmanager.MonitoredTxStatusDone:
+		_, mined := blobTxMined(result)
+		return !mined
+	default:
+		return false
+	}
+}
+
+// GetSequence gets the sequence data from the blob sidecar source
+func (backend *EthBlobDABackend) GetSequence(ctx context.Context, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
+	blobData, err := TryDecodeFromDataAvailabilityMessage(dataAvailabilityMessage)
+	if err != nil {
+		log.Error("Error decoding from da message: ", err)
+		return nil, err
+	}
+
+	blobs := make([]kzg4844.Blob, 0, len(blobData.VersionedHashes))
+	for _, vh := range blobData.Hashes() {
+		sidecar, err := backend.source.GetBlobSidecar(ctx, vh)
+		if err != nil {
+			log.Errorf("Error retrieving blob %s from sidecar source: %v", vh.String(), err)
+			return nil, err
+		}
+		if common.Hash(versionedHash(sidecar.Commitment)) != vh {
+			return nil, fmt.Errorf("blob commitment does not match versioned hash %s", vh.String())
+		}
+		err = kzg4844.VerifyBlobProof(sidecar.Blob, sidecar.Commitment, sidecar.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob proof for versioned hash %s: %w", vh.String(), err)
+		}
+		blobs = append(blobs, sidecar.Blob)
+	}
+
+	data, err := DecodeBlobs(blobs)
+	if err != nil {
+		return nil, err
+	}
+	batchesData, _ := nubit.DecodeSequence(data)
+
+	// Check the retrieved data against the expected batch hashes, if provided
+	if len(batchHashes) > 0 {
+		if len(batchHashes) != len(batchesData) {
+			return nil, fmt.Errorf("expected %d batches, retrieved %d", len(batchHashes), len(batchesData))
+		}
+		for i, batchData := range batchesData {
+			actualHash := crypto.Keccak256Hash(batchData)
+			if actualHash != batchHashes[i] {
+				return nil, fmt.Errorf("mismatch on batch data. Expected hash %s, actual hash: %s", batchHashes[i], actualHash)
+			}
+		}
+	}
+	return batchesData, nil
+}
@@ -0,0 +1,181 @@
+package ethblob
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	configTypes "github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/sieniven/zkevm-nubit/dataavailability/nubit"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEthTxManager confirms every blob tx added to it, unless its status is set otherwise
+type mockEthTxManager struct {
+	sidecars map[string]*types.BlobTxSidecar
+	statuses map[string]ethtxmanager.MonitoredTxStatus
+}
+
+func (m *mockEthTxManager) AddBlob(ctx context.Context, owner, id string, from common.Address, to *common.Address, sidecar *types.BlobTxSidecar, gasOffset uint64) error {
+	if _, found := m.sidecars[id]; found {
+		return ethtxmanager.ErrAlreadyExists
+	}
+	m.sidecars[id] = sidecar
+	if _, found := m.statuses[id]; !found {
+		m.statuses[id] = ethtxmanager.MonitoredTxStatusConfirmed
+	}
+	return nil
+}
+
+func (m *mockEthTxManager) Result(ctx context.Context, owner, id string) (ethtxmanager.MonitoredTxResult, error) {
+	if _, found := m.sidecars[id]; !found {
+		return ethtxmanager.MonitoredTxResult{}, ethtxmanager.ErrNotFound
+	}
+	result := ethtxmanager.MonitoredTxResult{
+		ID:     id,
+		Status: m.statuses[id],
+		Txs:    map[common.Hash]ethtxmanager.TxResult{},
+	}
+	txHash := crypto.Keccak256Hash([]byte(id))
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash, BlockNumber: big.NewInt(1)}
+	if result.Status == ethtxmanager.MonitoredTxStatusFailed {
+		receipt.Status = types.ReceiptStatusFailed
+	}
+	result.Txs[txHash] = ethtxmanager.TxResult{Receipt: receipt}
+	return result, nil
+}
+
+func (m *mockEthTxManager) ProcessMonitoredTxs(ctx context.Context, owner string, resultHandler ethtxmanager.ResultHandler) (int, error) {
+	for id, status := range m.statuses {
+		if status == ethtxmanager.MonitoredTxStatusConfirmed || status == ethtxmanager.MonitoredTxStatusFailed {
+			m.statuses[id] = ethtxmanager.MonitoredTxStatusDone
+		}
+	}
+	return 0, nil
+}
+
+func newTestingBackend(t *testing.T) (*EthBlobDABackend, *mockEthTxManager) {
+	t.Helper()
+	cfg := Config{
+		BlobSenderAddress:          common.HexToAddress("0x1"),
+		BlobConfirmationMaxRetry:   1,
+		BlobConfirmationWaitPeriod: configTypes.NewDuration(time.Millisecond),
+	}
+	store, err := NewFileSidecarStore(t.TempDir())
+	require.NoError(t, err)
+
+	etm := &mockEthTxManager{
+		sidecars: map[string]*types.BlobTxSidecar{},
+		statuses: map[string]ethtxmanager.MonitoredTxStatus{},
+	}
+	backend, err := NewEthBlobDABackend(&cfg, etm, store)
+	require.NoError(t, err)
+	return backend, etm
+}
+
+func TestBlobPipeline(t *testing.T) {
+	backend, etm := newTestingBackend(t)
+
+	// Generate mock sequence spanning over multiple blobs
+	mockBatches := [][]byte{}
+	mockHashes := []common.Hash{}
+	for i := 0; i < 3; i++ {
+		data := make([]byte, 80000)
+		_, err := rand.Read(data) //nolint:gosec,staticcheck
+		require.NoError(t, err)
+		mockBatches = append(mockBatches, data)
+		mockHashes = append(mockHashes, crypto.Keccak256Hash(data))
+	}
+
+	msg, err := backend.PostSequence(context.Background(), mockBatches)
+	require.NoError(t, err)
+	require.Len(t, etm.sidecars, 1)
+
+	blobData, err := TryDecodeFromDataAvailabilityMessage(msg)
+	require.NoError(t, err)
+	assert.Len(t, blobData.VersionedHashes, 2)
+	for _, sidecar := range etm.sidecars {
+		assert.Equal(t, sidecar.BlobHashes(), blobData.Hashes())
+	}
+
+	// Retrieve sequence from the sidecar store
+	returnData, err := backend.GetSequence(context.Background(), mockHashes, msg)
+	require.NoError(t, err)
+	assert.Equal(t, mockBatches, returnData)
+
+	// Retrieving with wrong batch hashes fails
+	_, err = backend.GetSequence(context.Background(), []common.Hash{{}, {}, {}}, msg)
+	assert.Error(t, err)
+}
+
+func TestBlobPipelineRepost(t *testing.T) {
+	backend, etm := newTestingBackend(t)
+	batches := [][]byte{{0x01}, {0x02}}
+
+	msg, err := backend.PostSequence(context.Background(), batches)
+	require.NoError(t, err)
+	require.Len(t, etm.sidecars, 1)
+	for id, status := range etm.statuses {
+		assert.Equal(t, ethtxmanager.MonitoredTxStatusDone, status, id)
+	}
+
+	// Reposting the same batches reuses the confirmed blob tx
+	repostMsg, err := backend.PostSequence(context.Background(), batches)
+	require.NoError(t, err)
+	assert.Len(t, etm.sidecars, 1)
+	assert.Equal(t, msg, repostMsg)
+
+	// Reposting the same batches after the blob tx failed adds a new one
+	for id := range etm.statuses {
+		etm.statuses[id] = ethtxmanager.MonitoredTxStatusFailed
+	}
+	repostMsg, err = backend.PostSequence(context.Background(), batches)
+	require.NoError(t, err)
+	assert.Len(t, etm.sidecars, 2)
+	assert.NotEqual(t, msg, repostMsg)
+}
+
+func TestBlobPipelineFailed(t *testing.T) {
+	backend, etm := newTestingBackend(t)
+	batches := [][]byte{{0x01}, {0x02}}
+
+	// The blob tx of the first attempt failed, and the one of the second attempt is canceled
+	blobs, err := EncodeBlobs(nubit.EncodeSequence(batches))
+	require.NoError(t, err)
+	sidecar, err := NewBlobTxSidecar(blobs)
+	require.NoError(t, err)
+	vh := sidecar.BlobHashes()[0].Hex()
+	etm.sidecars[fmt.Sprintf(monitoredIDFormat, vh)] = sidecar
+	etm.statuses[fmt.Sprintf(monitoredIDFormat, vh)] = ethtxmanager.MonitoredTxStatusFailed
+	etm.statuses[fmt.Sprintf(monitoredIDAttemptFormat, vh, 1)] = ethtxmanager.MonitoredTxStatusCanceled
+
+	_, err = backend.PostSequence(context.Background(), batches)
+	assert.ErrorContains(t, err, "blob tx canceled")
+	assert.Len(t, etm.sidecars, 2)
+	assert.Contains(t, etm.sidecars, fmt.Sprintf(monitoredIDAttemptFormat, vh, 1))
+}
+
+func TestBlobPipelineMissingSidecar(t *testing.T) {
+	backend, _ := newTestingBackend(t)
+
+	msg, err := TryEncodeToDataAvailabilityMessage(BlobData{VersionedHashes: [][32]byte{{0x01}}})
+	require.NoError(t, err)
+
+	_, err = backend.GetSequence(context.Background(), nil, msg)
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestNewEthBlobDABackendRequiresSender(t *testing.T) {
+	store, err := NewFileSidecarStore(t.TempDir())
+	require.NoError(t, err)
+	_, err = NewEthBlobDABackend(&Config{}, &mockEthTxManager{}, store)
+	assert.ErrorContains(t, err, "blob sender address not provided")
+}
@@ -0,0 +1,81 @@
+package ethblob
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrConvertFromABIInterface is used when there is a decoding error
+var ErrConvertFromABIInterface = errors.New("conversion from abi interface error")
+
+// BlobData is the data availability message of a sequence posted as Ethereum blobs. It
+// references the L1 blob tx and the versioned hashes of the blobs carrying the sequence.
+type BlobData struct {
+	TxHash          [32]byte   `abi:"txHash"`
+	VersionedHashes [][32]byte `abi:"versionedHashes"`
+}
+
+// TryEncodeToDataAvailabilityMessage is a fallible encoding method to encode
+// Ethereum blob data into data availability message represented as byte array.
+func TryEncodeToDataAvailabilityMessage(blobData BlobData) ([]byte, error) {
+	parsedABI, err := abi.JSON(bytes.NewReader([]byte(blobDataABI)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode the data
+	method, exist := parsedABI.Methods["BlobData"]
+	if !exist {
+		return nil, fmt.Errorf("abi error, BlobData method not found")
+	}
+
+	encoded, err := method.Inputs.Pack(blobData)
+	if err != nil {
+		return nil, err
+	}
+
+	return encoded, nil
+}
+
+// TryDecodeFromDataAvailabilityMessage is a fallible decoding method to
+// decode data availability message into Ethereum blob data.
+func TryDecodeFromDataAvailabilityMessage(msg []byte) (BlobData, error) {
+	// Parse the ABI
+	parsedABI, err := abi.JSON(bytes.NewReader([]byte(blobDataABI)))
+	if err != nil {
+		return BlobData{}, err
+	}
+
+	// Decode the data
+	method, exist := parsedABI.Methods["BlobData"]
+	if !exist {
+		return BlobData{}, fmt.Errorf("abi error, BlobData method not found")
+	}
+
+	unpacked, err := method.Inputs.Unpack(msg)
+	if err != nil {
+		return BlobData{}, err
+	}
+	if len(unpacked) != 1 {
+		return BlobData{}, fmt.Errorf("abi error, failed to unpack to BlobData")
+	}
+
+	blobData, ok := abi.ConvertType(unpacked[0], new(BlobData)).(*BlobData)
+	if !ok {
+		return BlobData{}, ErrConvertFromABIInterface
+	}
+	return *blobData, nil
+}
+
+// Hashes returns the versioned hashes as common hashes
+func (b BlobData) Hashes() []common.Hash {
+	hashes := make([]common.Hash, 0, len(b.VersionedHashes))
+	for _, h := range b.VersionedHashes {
+		hashes = append(hashes, common.Hash(h))
+	}
+	return hashes
+}
@@ -0,0 +1,21 @@
+package ethblob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeBlobData(t *testing.T) {
+	data := BlobData{
+		TxHash:          [32]byte{0x01},
+		VersionedHashes: [][32]byte{{0x01, 0x02}, {0x01, 0x03}},
+	}
+	msg, err := TryEncodeToDataAvailabilityMessage(data)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, msg)
+
+	decodedData, err := TryDecodeFromDataAvailabilityMessage(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decodedData)
+}
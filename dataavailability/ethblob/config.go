@@ -0,0 +1,47 @@
+package ethblob
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sieniven/zkevm-nubit/config/types"
+)
+
+// BlobFieldElements is the number of field elements in a single blob.
+const BlobFieldElements = 4096
+
+// BlobFieldElementUsableBytes is the number of bytes that can be stored in a single field
+// element. The most significant byte is always left empty so that the field element stays
+// below the BLS12-381 modulus.
+const BlobFieldElementUsableBytes = 31
+
+// BlobUsableBytes is the number of bytes that can be stored in a single blob.
+const BlobUsableBytes = BlobFieldElements * BlobFieldElementUsableBytes
+
+// MaxBlobsPerTx is the maximum amount of blobs that can be carried by a single blob tx.
+const MaxBlobsPerTx = 6
+
+// DefaultConfirmationWaitPeriod is the wait period used between blob tx confirmation checks
+// if none is configured.
+const DefaultConfirmationWaitPeriod time.Duration = 12 * time.Second
+
+// Config is the Ethereum blobs DA backend configurations
+type Config struct {
+	// BlobSenderAddress defines which private key the eth tx manager needs to use
+	// to sign the blob txs
+	BlobSenderAddress common.Address `mapstructure:"BlobSenderAddress"`
+
+	// BlobSidecarStoragePath is the directory where the posted blob sidecars are
+	// stored, and read back from when retrieving sequences
+	BlobSidecarStoragePath string `mapstructure:"BlobSidecarStoragePath"`
+
+	// BlobGasOffset is the amount of gas to be added to the gas estimation of the blob tx
+	BlobGasOffset uint64 `mapstructure:"BlobGasOffset"`
+
+	// BlobConfirmationMaxRetry is the maximum amount of times the blob tx result is checked
+	// before giving up on the blob tx confirmation
+	BlobConfirmationMaxRetry uint64 `mapstructure:"BlobConfirmationMaxRetry"`
+
+	// BlobConfirmationWaitPeriod is the time waited between blob tx result checks
+	BlobConfirmationWaitPeriod types.Duration `mapstructure:"BlobConfirmationWaitPeriod"`
+}
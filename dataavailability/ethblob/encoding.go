@@ -0,0 +1,90 @@
+package ethblob
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// blobDataLengthBytes is the amount of bytes used to store the payload length in the first blob
+const blobDataLengthBytes = 8
+
+// EncodeBlobs is the helper function to pack a byte array into KZG blobs.
+//
+// The first 8-bytes of the packed payload stores the length of the data, so that the blob
+// padding can be trimmed when decoding. The payload is then split into chunks of 31 bytes,
+// and each chunk is stored in a field element with its most significant byte left empty.
+func EncodeBlobs(data []byte) ([]kzg4844.Blob, error) {
+	payload := make([]byte, blobDataLengthBytes, blobDataLengthBytes+len(data))
+	binary.BigEndian.PutUint64(payload, uint64(len(data)))
+	payload = append(payload, data...)
+
+	n := (len(payload) + BlobUsableBytes - 1) / BlobUsableBytes
+	if n > MaxBlobsPerTx {
+		return nil, fmt.Errorf("data of %d bytes does not fit in %d blobs", len(data), MaxBlobsPerTx)
+	}
+
+	blobs := make([]kzg4844.Blob, n)
+	for i := 0; i < n; i++ {
+		chunk := payload[i*BlobUsableBytes : min((i+1)*BlobUsableBytes, len(payload))]
+		for j := 0; j*BlobFieldElementUsableBytes < len(chunk); j++ {
+			element := chunk[j*BlobFieldElementUsableBytes : min((j+1)*BlobFieldElementUsableBytes, len(chunk))]
+			copy(blobs[i][j*32+1:], element)
+		}
+	}
+	return blobs, nil
+}
+
+// DecodeBlobs is the helper function to unpack KZG blobs into the original byte array. The
+// decoding scheme follows the encoding scheme specified in the EncodeBlobs function.
+func DecodeBlobs(blobs []kzg4844.Blob) ([]byte, error) {
+	payload := make([]byte, 0, len(blobs)*BlobUsableBytes)
+	for _, blob := range blobs {
+		for j := 0; j < BlobFieldElements; j++ {
+			if blob[j*32] != 0 {
+				return nil, fmt.Errorf("invalid field element %d in blob, most significant byte is not empty", j)
+			}
+			payload = append(payload, blob[j*32+1:(j+1)*32]...)
+		}
+	}
+	if len(payload) < blobDataLengthBytes {
+		return nil, fmt.Errorf("invalid blobs, payload length not found")
+	}
+
+	n := binary.BigEndian.Uint64(payload[:blobDataLengthBytes])
+	if n > uint64(len(payload)-blobDataLengthBytes) {
+		return nil, fmt.Errorf("invalid blobs, data length %d exceeds the blobs capacity", n)
+	}
+	return payload[blobDataLengthBytes : blobDataLengthBytes+n], nil
+}
+
+// NewBlobTxSidecar computes the KZG commitments and proofs of the blobs, and returns the
+// sidecar to be carried by the blob tx
+func NewBlobTxSidecar(blobs []kzg4844.Blob) (*types.BlobTxSidecar, error) {
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       blobs,
+		Commitments: make([]kzg4844.Commitment, 0, len(blobs)),
+		Proofs:      make([]kzg4844.Proof, 0, len(blobs)),
+	}
+	for _, blob := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(blob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob commitment: %w", err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob proof: %w", err)
+		}
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+	}
+	return sidecar, nil
+}
+
+// versionedHash returns the versioned hash of the provided blob commitment
+func versionedHash(commitment kzg4844.Commitment) [32]byte {
+	return kzg4844.CalcBlobHashV1(sha256.New(), &commitment)
+}
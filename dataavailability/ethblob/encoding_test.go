@@ -0,0 +1,50 @@
+package ethblob
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeBlobs(t *testing.T) {
+	// Define different data sizes, including the boundaries of a single blob
+	dataSize := []int{0, 1, 80, BlobUsableBytes - blobDataLengthBytes, BlobUsableBytes, 300000}
+
+	for _, size := range dataSize {
+		data := make([]byte, size)
+		_, err := rand.Read(data) //nolint:gosec,staticcheck
+		require.NoError(t, err)
+
+		blobs, err := EncodeBlobs(data)
+		require.NoError(t, err)
+		assert.Equal(t, (size+blobDataLengthBytes+BlobUsableBytes-1)/BlobUsableBytes, len(blobs))
+
+		// Assert the most significant byte of each field element is empty
+		for _, blob := range blobs {
+			for i := 0; i < BlobFieldElements; i++ {
+				require.Zero(t, blob[i*32])
+			}
+		}
+
+		decoded, err := DecodeBlobs(blobs)
+		require.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+}
+
+func TestEncodeBlobsTooBig(t *testing.T) {
+	data := make([]byte, MaxBlobsPerTx*BlobUsableBytes)
+	_, err := EncodeBlobs(data)
+	assert.Error(t, err)
+}
+
+func TestDecodeBlobsInvalidFieldElement(t *testing.T) {
+	blobs, err := EncodeBlobs([]byte("hihihihihihihihihihihihihihihihihihi"))
+	require.NoError(t, err)
+
+	blobs[0][32] = 0x01
+	_, err = DecodeBlobs(blobs)
+	assert.Error(t, err)
+}
@@ -0,0 +1,15 @@
+package ethblob
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+)
+
+type ethTxManager interface {
+	AddBlob(ctx context.Context, owner, id string, from common.Address, to *common.Address, sidecar *types.BlobTxSidecar, gasOffset uint64) error
+	Result(ctx context.Context, owner, id string) (ethtxmanager.MonitoredTxResult, error)
+	ProcessMonitoredTxs(ctx context.Context, owner string, resultHandler ethtxmanager.ResultHandler) (int, error)
+}
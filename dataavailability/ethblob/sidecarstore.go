@@ -0,0 +1,90 @@
+package ethblob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// ErrBlobNotFound is used when the blob is not available in the sidecar source
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobSidecar is a single blob with its KZG commitment and proof
+type BlobSidecar struct {
+	Blob       kzg4844.Blob       `json:"blob"`
+	Commitment kzg4844.Commitment `json:"commitment"`
+	Proof      kzg4844.Proof      `json:"proof"`
+}
+
+// BlobSidecarSource is used to retrieve blob sidecars by their versioned hash
+type BlobSidecarSource interface {
+	// GetBlobSidecar retrieves the blob sidecar matching the versioned hash
+	GetBlobSidecar(ctx context.Context, versionedHash common.Hash) (BlobSidecar, error)
+}
+
+// BlobSidecarStorer is used to keep a copy of the posted blob sidecars
+type BlobSidecarStorer interface {
+	// StoreBlobSidecar stores the blob sidecar by its versioned hash
+	StoreBlobSidecar(ctx context.Context, versionedHash common.Hash, sidecar BlobSidecar) error
+}
+
+// FileSidecarStore is a local blob sidecar store that keeps each blob sidecar in a
+// JSON file named after its versioned hash
+type FileSidecarStore struct {
+	dir string
+}
+
+// NewFileSidecarStore is the factory method to create a new instance of FileSidecarStore
+func NewFileSidecarStore(dir string) (*FileSidecarStore, error) {
+	if dir == "" {
+		return nil, errors.New("blob sidecar storage path not configured")
+	}
+	err := os.MkdirAll(dir, 0o750) //nolint:gomnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob sidecar storage dir: %w", err)
+	}
+	return &FileSidecarStore{dir: dir}, nil
+}
+
+// GetBlobSidecar reads the blob sidecar matching the versioned hash from disk
+func (s *FileSidecarStore) GetBlobSidecar(ctx context.Context, versionedHash common.Hash) (BlobSidecar, error) {
+	raw, err := os.ReadFile(s.path(versionedHash))
+	if errors.Is(err, os.ErrNotExist) {
+		return BlobSidecar{}, ErrBlobNotFound
+	} else if err != nil {
+		return BlobSidecar{}, err
+	}
+
+	var sidecar BlobSidecar
+	err = json.Unmarshal(raw, &sidecar)
+	if err != nil {
+		return BlobSidecar{}, fmt.Errorf("failed to decode blob sidecar %s: %w", versionedHash.String(), err)
+	}
+	return sidecar, nil
+}
+
+// StoreBlobSidecar writes the blob sidecar to disk
+func (s *FileSidecarStore) StoreBlobSidecar(ctx context.Context, versionedHash common.Hash, sidecar BlobSidecar) error {
+	raw, err := json.Marshal(sidecar)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a sidecar is never partially written
+	tmp := s.path(versionedHash) + ".tmp"
+	err = os.WriteFile(tmp, raw, 0o600) //nolint:gomnd
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(versionedHash))
+}
+
+func (s *FileSidecarStore) path(versionedHash common.Hash) string {
+	return filepath.Join(s.dir, versionedHash.Hex()+".json")
+}
@@ -2,7 +2,6 @@ package nubit
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"encoding/hex"
 	"strings"
 	"time"
@@ -12,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rollkit/go-da"
 	"github.com/rollkit/go-da/proxy"
+	"github.com/sieniven/zkevm-nubit/signer"
 )
 
 // NubitDABackend implements the DA integration with Nubit DA layer
@@ -19,14 +19,14 @@ type NubitDABackend struct {
 	client     da.DA
 	config     *Config
 	namespace  da.Namespace
-	privKey    *ecdsa.PrivateKey
+	signer     signer.Signer
 	commitTime time.Time
 }
 
 // NewNubitDABackend is the factory method to create a new instance of NubitDABackend
 func NewNubitDABackend(
 	cfg *Config,
-	privKey *ecdsa.PrivateKey,
+	daSigner signer.Signer,
 ) (*NubitDABackend, error) {
 	log.Infof("NubitDABackend config: %#v", cfg)
 	cn, err := proxy.NewClient(cfg.NubitRpcURL, cfg.NubitAuthKey)
@@ -47,7 +47,7 @@ func NewNubitDABackend(
 
 	return &NubitDABackend{
 		config:     cfg,
-		privKey:    privKey,
+		signer:     daSigner,
 		namespace:  name,
 		client:     cn,
 		commitTime: time.Now(),
@@ -109,9 +109,9 @@ func (backend *NubitDABackend) PostSequence(ctx context.Context, batchesData [][
 	for _, seq := range batchesData {
 		sequence = append(sequence, seq)
 	}
-	signedSequence, err := sequence.Sign(backend.privKey)
+	signedSequence, err := signer.SignSequence(ctx, backend.signer, sequence)
 	if err != nil {
-		log.Errorf("Failed to sign sequence with pk: %v", err)
+		log.Errorf("Failed to sign sequence: %v", err)
 		return nil, err
 	}
 	signature := append(sequence.HashToSign(), signedSequence.Signature...)
@@ -2,9 +2,6 @@ package nubit
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	crand "crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math/rand"
@@ -12,9 +9,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rollkit/go-da/proxy"
 	"github.com/sieniven/zkevm-nubit/config/types"
 	"github.com/sieniven/zkevm-nubit/log"
+	"github.com/sieniven/zkevm-nubit/signer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,10 +26,10 @@ func TestOffchainPipeline(t *testing.T) {
 		NubitGetProofMaxRetry:   10,
 		NubitGetProofWaitPeriod: types.NewDuration(5 * time.Second),
 	}
-	pk, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	pk, err := crypto.GenerateKey()
 	require.NoError(t, err)
 
-	backend, err := NewNubitDABackend(&cfg, pk)
+	backend, err := NewNubitDABackend(&cfg, signer.NewPrivateKeySigner(pk, 1))
 	require.NoError(t, err)
 
 	// Generate mock string batch data
@@ -75,10 +74,10 @@ func TestOffchainPipelineWithRandomData(t *testing.T) {
 		NubitGetProofMaxRetry:   10,
 		NubitGetProofWaitPeriod: types.NewDuration(5 * time.Second),
 	}
-	pk, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	pk, err := crypto.GenerateKey()
 	require.NoError(t, err)
 
-	backend, err := NewNubitDABackend(&cfg, pk)
+	backend, err := NewNubitDABackend(&cfg, signer.NewPrivateKeySigner(pk, 1))
 	require.NoError(t, err)
 
 	// Define Different DataSizes
@@ -120,7 +119,7 @@ func TestOffchainPipelineWithRandomData(t *testing.T) {
 	}
 }
 
-func NewMockNubitDABackend(url string, authKey string, pk *ecdsa.PrivateKey) (*NubitDABackend, error) {
+func NewMockNubitDABackend(url string, authKey string, daSigner signer.Signer) (*NubitDABackend, error) {
 	cn, err := proxy.NewClient(url, authKey)
 	if err != nil || cn == nil {
 		return nil, err
@@ -135,7 +134,7 @@ func NewMockNubitDABackend(url string, authKey string, pk *ecdsa.PrivateKey) (*N
 	return &NubitDABackend{
 		namespace:  name,
 		client:     cn,
-		privKey:    pk,
+		signer:     daSigner,
 		commitTime: time.Now(),
 	}, nil
 }
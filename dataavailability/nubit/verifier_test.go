@@ -0,0 +1,187 @@
+package nubit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rollkit/go-da"
+	"github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/sieniven/zkevm-nubit/etherman/smartcontracts/nubitdaverifier"
+	polygonzkevm "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonvalidium_xlayer"
+	"github.com/sieniven/zkevm-nubit/signer"
+	"github.com/sieniven/zkevm-nubit/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNubitDA stores the submitted blobs in memory
+type fakeNubitDA struct {
+	da.DA
+	blobs [][]byte
+}
+
+func (d *fakeNubitDA) Submit(ctx context.Context, blobs []da.Blob, gasPrice float64, namespace da.Namespace) ([]da.ID, error) {
+	var ids []da.ID
+	for _, blob := range blobs {
+		d.blobs = append(d.blobs, blob)
+		ids = append(ids, crypto.Keccak256(blob))
+	}
+	return ids, nil
+}
+
+func (d *fakeNubitDA) GetProofs(ctx context.Context, ids []da.ID, namespace da.Namespace) ([]da.Proof, error) {
+	var proofs []da.Proof
+	for range ids {
+		proofs = append(proofs, []byte{1})
+	}
+	return proofs, nil
+}
+
+// newTestingNubitDABackend creates a backend signing with the key over the fake NubitDA
+func newTestingNubitDABackend(key *ecdsa.PrivateKey) *NubitDABackend {
+	return &NubitDABackend{
+		client:    &fakeNubitDA{},
+		config:    &Config{NubitGetProofMaxRetry: 1, NubitGetProofWaitPeriod: types.NewDuration(time.Millisecond)},
+		namespace: []byte("xlayer"),
+		signer:    signer.NewPrivateKeySigner(key, testutil.ChainID),
+	}
+}
+
+// newTestingVerifierEnv deploys the validium with the NubitDA verifier accepting the messages
+// signed by the DA key
+func newTestingVerifierEnv(t *testing.T) (*testutil.SimulatedL1, *nubitdaverifier.Nubitdaverifier, *ecdsa.PrivateKey) {
+	t.Helper()
+	daKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	l1 := testutil.NewSimulatedL1(t, testutil.Config{
+		FundedKeys:       1,
+		DeployDAProtocol: testutil.DeployNubitDAVerifier(crypto.PubkeyToAddress(daKey.PublicKey)),
+	})
+	verifier, err := nubitdaverifier.NewNubitdaverifier(l1.DAProtocol, l1.Backend)
+	require.NoError(t, err)
+	return l1, verifier, daKey
+}
+
+// accInputHash returns the accumulated hash of the batches transactions hashes the validium
+// verifies the data availability message with, and the validium batches
+func accInputHash(batchesData [][]byte) (common.Hash, []polygonzkevm.PolygonValidiumEtrogValidiumBatchData) {
+	hash := common.Hash{}
+	var batches []polygonzkevm.PolygonValidiumEtrogValidiumBatchData
+	for _, batchData := range batchesData {
+		transactionsHash := crypto.Keccak256Hash(batchData)
+		hash = crypto.Keccak256Hash(hash.Bytes(), transactionsHash.Bytes())
+		batches = append(batches, polygonzkevm.PolygonValidiumEtrogValidiumBatchData{TransactionsHash: transactionsHash})
+	}
+	return hash, batches
+}
+
+// assertCustomError asserts the call reverted with the custom error of the verifier
+func assertCustomError(t *testing.T, err error, name string) {
+	t.Helper()
+	verifierABI, abiErr := nubitdaverifier.NubitdaverifierMetaData.GetAbi()
+	require.NoError(t, abiErr)
+	var dataErr rpc.DataError
+	require.ErrorAs(t, err, &dataErr)
+	customError, ok := verifierABI.Errors[name]
+	require.True(t, ok)
+	assert.Equal(t, hexutil.Encode(customError.ID.Bytes()[:4]), dataErr.ErrorData())
+}
+
+func TestNubitDAVerifierAcceptsPostedSequence(t *testing.T) {
+	ctx := context.Background()
+	l1, verifier, daKey := newTestingVerifierEnv(t)
+	name, err := verifier.GetProcotolName(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, "NubitDA", name)
+
+	batchesData := [][]byte{{0x0b, 0x01}, {0x0b, 0x02, 0x03}}
+	msg, err := newTestingNubitDABackend(daKey).PostSequence(ctx, batchesData)
+	require.NoError(t, err)
+	hash, batches := accInputHash(batchesData)
+	require.NoError(t, verifier.VerifyMessage(&bind.CallOpts{}, hash, msg))
+
+	// The validium accepts the sequence with the message
+	admin := crypto.PubkeyToAddress(l1.Admin.PublicKey)
+	tx, err := l1.Validium.SequenceBatchesValidium(l1.Auth(t, l1.Admin), batches, 1, 1, admin, msg)
+	require.NoError(t, err)
+	l1.Backend.Commit()
+	receipt, err := l1.Backend.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Status)
+	event, err := l1.Etherman.GetLastSequencedBatch(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), event.BatchNumber)
+}
+
+func TestNubitDAVerifierRejectsInvalidMessages(t *testing.T) {
+	ctx := context.Background()
+	_, verifier, daKey := newTestingVerifierEnv(t)
+	batchesData := [][]byte{{0x0b, 0x01}}
+	hash, _ := accInputHash(batchesData)
+	msg, err := newTestingNubitDABackend(daKey).PostSequence(ctx, batchesData)
+	require.NoError(t, err)
+	blobData, err := TryDecodeFromDataAvailabilityMessage(msg)
+	require.NoError(t, err)
+
+	// Hash of other batches
+	otherHash, _ := accInputHash([][]byte{{0x0b, 0x02}})
+	assertCustomError(t, verifier.VerifyMessage(&bind.CallOpts{}, otherHash, msg), "UnexpectedHash")
+
+	// Signed by another key
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherMsg, err := newTestingNubitDABackend(otherKey).PostSequence(ctx, batchesData)
+	require.NoError(t, err)
+	assertCustomError(t, verifier.VerifyMessage(&bind.CallOpts{}, hash, otherMsg), "InvalidSignature")
+
+	// Truncated signature
+	truncated := blobData
+	truncated.Signature = blobData.Signature[:96]
+	truncatedMsg, err := TryEncodeToDataAvailabilityMessage(truncated)
+	require.NoError(t, err)
+	assertCustomError(t, verifier.VerifyMessage(&bind.CallOpts{}, hash, truncatedMsg), "InvalidSignatureLength")
+
+	// Malformed messages
+	assert.Error(t, verifier.VerifyMessage(&bind.CallOpts{}, hash, []byte{}))
+	assert.Error(t, verifier.VerifyMessage(&bind.CallOpts{}, hash, msg[:len(msg)-32]))
+}
+
+func TestNubitDAVerifierSetSequencer(t *testing.T) {
+	ctx := context.Background()
+	l1, verifier, daKey := newTestingVerifierEnv(t)
+	owner, err := verifier.Owner(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(l1.Admin.PublicKey), owner)
+	sequencer, err := verifier.Sequencer(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(daKey.PublicKey), sequencer)
+
+	// Only the owner updates the sequencer
+	newKey := l1.Keys[0]
+	newSequencer := crypto.PubkeyToAddress(newKey.PublicKey)
+	_, err = verifier.SetSequencer(l1.Auth(t, newKey), newSequencer)
+	assertCustomError(t, err, "OnlyOwner")
+	_, err = verifier.SetSequencer(l1.Auth(t, l1.Admin), newSequencer)
+	require.NoError(t, err)
+	l1.Backend.Commit()
+	events, err := verifier.FilterSetSequencer(&bind.FilterOpts{Context: ctx})
+	require.NoError(t, err)
+	require.True(t, events.Next())
+	assert.Equal(t, newSequencer, events.Event.NewSequencer)
+
+	batchesData := [][]byte{{0x0b, 0x01}}
+	hash, _ := accInputHash(batchesData)
+	msg, err := newTestingNubitDABackend(daKey).PostSequence(ctx, batchesData)
+	require.NoError(t, err)
+	assertCustomError(t, verifier.VerifyMessage(&bind.CallOpts{}, hash, msg), "InvalidSignature")
+	msg, err = newTestingNubitDABackend(newKey).PostSequence(ctx, batchesData)
+	require.NoError(t, err)
+	assert.NoError(t, verifier.VerifyMessage(&bind.CallOpts{}, hash, msg))
+}
@@ -1,6 +1,10 @@
 package etherman
 
+import "github.com/sieniven/zkevm-nubit/etherman/gasoracle"
+
 type Config struct {
 	// URL is the URL of the Ethereum node for L1
 	URL string `mapstructure:"URL"`
+	// GasPrice configures the gas price sources and how their gas prices are aggregated
+	GasPrice gasoracle.Config `mapstructure:"GasPrice"`
 }
@@ -3,28 +3,30 @@ package etherman
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
+	configTypes "github.com/sieniven/zkevm-nubit/config/types"
 	"github.com/sieniven/zkevm-nubit/dataavailability"
+	"github.com/sieniven/zkevm-nubit/etherman/gasoracle"
 	dataavailabilityprotocol "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/dataavailabilityprotocol_xlayer"
+	"github.com/sieniven/zkevm-nubit/etherman/smartcontracts/nubitdaverifier"
 	"github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonrollupmanager"
 	polygonzkevm "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonvalidium_xlayer"
 	ethmanTypes "github.com/sieniven/zkevm-nubit/etherman/types"
 	"github.com/sieniven/zkevm-nubit/log"
+	"github.com/sieniven/zkevm-nubit/signer"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -33,6 +35,7 @@ import (
 
 var (
 	sequenceBatchesSignatureHash = crypto.Keccak256Hash([]byte("SequenceBatches(uint64,bytes32)")) // Used in oldZkEvm as well
+	forceBatchSignatureHash      = crypto.Keccak256Hash([]byte("ForceBatch(uint64,bytes32,address,bytes)"))
 	// methodIDSequenceBatchesEtrog: MethodID for sequenceBatches in Etrog
 	methodIDSequenceBatchesEtrog = []byte{0xec, 0xef, 0x3f, 0x99} // nolint:unused // 0xecef3f99
 	// methodIDSequenceBatchesElderberry: MethodID for sequenceBatches in Elderberry
@@ -43,11 +46,6 @@ var (
 	methodIDSequenceBatchesValidiumElderberry = []byte{0xdb, 0x5b, 0x0e, 0xd7} // 0xdb5b0ed7 sequenceBatchesValidium((bytes32,bytes32,uint64,bytes32)[],uint64,uint64,address,bytes)
 )
 
-type externalGasProviders struct {
-	MultiGasProvider bool
-	Providers        []ethereum.GasPricer
-}
-
 // Minimal implementation of PolygonCDK's ether manager
 type Client struct {
 	EthClient     ethereumClient
@@ -55,10 +53,10 @@ type Client struct {
 	RollupManager *polygonrollupmanager.Polygonrollupmanager
 	SCAddresses   []common.Address
 	RollupID      uint32
-	GasProviders  externalGasProviders
+	GasOracle     *gasoracle.GasOracle
 	l1Cfg         L1Config
 	cfg           Config
-	auth          map[common.Address]bind.TransactOpts // empty in case of read-only client
+	auth          map[common.Address]signer.Signer // empty in case of read-only client
 	DAProtocol    *dataavailabilityprotocol.Dataavailabilityprotocol
 	da            dataavailability.BatchDataProvider
 }
@@ -69,14 +67,19 @@ type ethereumClient interface {
 	ethereum.ContractCaller
 	ethereum.GasEstimator
 	ethereum.GasPricer
+	ethereum.FeeHistoryReader
 	ethereum.LogFilterer
 	ethereum.TransactionReader
 	ethereum.TransactionSender
 	bind.DeployBackend
+	bind.ContractBackend
 }
 
 var ErrNotFound = errors.New("not found")
 
+// maxEventsBlockRange is the number of blocks of the L1 logs queries
+const maxEventsBlockRange = 10000
+
 // L1Config represents the configuration of the network used in L1
 type L1Config struct {
 	// Chain ID of the L1 network
@@ -94,17 +97,23 @@ func NewClient(cfg Config, l1Config L1Config) (*Client, error) {
 		fmt.Printf("error connecting to %s: %+v\n", cfg.URL, err)
 		return nil, err
 	}
-	// // Create smc clients
-	// zkevm, err := polygonzkevm.NewPolygonvalidiumXlayer(l1Config.ZkEVMAddr, ethClient)
-	// if err != nil {
-	// 	fmt.Printf("error creating Polygonzkevm client (%s)\n", l1Config.ZkEVMAddr.String())
-	// 	return nil, err
-	// }
-	// rollupManager, err := polygonrollupmanager.NewPolygonrollupmanager(l1Config.RollupManagerAddr, ethClient)
-	// if err != nil {
-	// 	fmt.Printf("error creating NewPolygonrollupmanager client (%s)\n", l1Config.RollupManagerAddr.String())
-	// 	return nil, err
-	// }
+	return NewClientFromEthClient(cfg, l1Config, ethClient)
+}
+
+// NewClientFromEthClient creates the ether manager over a connected L1 client, e.g. a simulated
+// backend. The contracts are not queried, so they do not need to be deployed yet.
+func NewClientFromEthClient(cfg Config, l1Config L1Config, ethClient ethereumClient) (*Client, error) {
+	// Create smc clients
+	zkevm, err := polygonzkevm.NewPolygonvalidiumXlayer(l1Config.ZkEVMAddr, ethClient)
+	if err != nil {
+		fmt.Printf("error creating Polygonzkevm client (%s)\n", l1Config.ZkEVMAddr.String())
+		return nil, err
+	}
+	rollupManager, err := polygonrollupmanager.NewPolygonrollupmanager(l1Config.RollupManagerAddr, ethClient)
+	if err != nil {
+		fmt.Printf("error creating NewPolygonrollupmanager client (%s)\n", l1Config.RollupManagerAddr.String())
+		return nil, err
+	}
 	// dapAddr, err := zkevm.DataAvailabilityProtocol(&bind.CallOpts{Pending: false})
 	// if err != nil {
 	// 	return nil, err
@@ -113,10 +122,14 @@ func NewClient(cfg Config, l1Config L1Config) (*Client, error) {
 	// if err != nil {
 	// 	return nil, err
 	// }
-	// var scAddresses []common.Address
-	// scAddresses = append(scAddresses, l1Config.ZkEVMAddr, l1Config.RollupManagerAddr)
+	var scAddresses []common.Address
+	scAddresses = append(scAddresses, l1Config.ZkEVMAddr, l1Config.RollupManagerAddr)
 
-	gProviders := []ethereum.GasPricer{ethClient}
+	gasOracle, err := gasoracle.New(cfg.GasPrice, ethClient)
+	if err != nil {
+		fmt.Printf("error creating gas price oracle: %+v\n", err)
+		return nil, err
+	}
 
 	// // get RollupID
 	// rollupID, err := rollupManager.RollupAddressToID(&bind.CallOpts{Pending: false}, l1Config.RollupManagerAddr)
@@ -125,19 +138,16 @@ func NewClient(cfg Config, l1Config L1Config) (*Client, error) {
 	// }
 
 	return &Client{
-		EthClient: ethClient,
-		// ZkEVM:         zkevm,
-		// RollupManager: rollupManager,
+		EthClient:     ethClient,
+		ZkEVM:         zkevm,
+		RollupManager: rollupManager,
 		// DAProtocol:    dap,
-		// SCAddresses:   scAddresses,
+		SCAddresses: scAddresses,
 		// RollupID:      rollupID,
-		GasProviders: externalGasProviders{
-			MultiGasProvider: false,
-			Providers:        gProviders,
-		},
-		l1Cfg: l1Config,
-		cfg:   cfg,
-		auth:  map[common.Address]bind.TransactOpts{},
+		GasOracle: gasOracle,
+		l1Cfg:     l1Config,
+		cfg:       cfg,
+		auth:      map[common.Address]signer.Signer{},
 	}, nil
 }
 
@@ -179,6 +189,17 @@ func (etherMan *Client) BuildMockSequenceBatchesTxData(sender common.Address, se
 // Mock function to replicate sequenceBatches on PolygonCDK
 // We will generate randomized []bytes to be sent to the mock PoE SC method SequenceBatchesValidium.
 func (etherMan *Client) sequenceBatches(opts bind.TransactOpts, sequences []ethmanTypes.Sequence, maxSequenceTimestamp uint64, lastSequencedBatchNumber uint64, l2Coinbase common.Address, dataAvailabilityMessage []byte) (*types.Transaction, error) {
+	batches := toValidiumBatchesData(sequences)
+	tx, err := etherMan.ZkEVM.SequenceBatchesValidium(&opts, batches, maxSequenceTimestamp, lastSequencedBatchNumber, l2Coinbase, dataAvailabilityMessage)
+	if err != nil {
+		fmt.Println("sequenceBatches failed")
+	}
+	return tx, err
+}
+
+// toValidiumBatchesData converts the sequences into the validium batches data of the contract,
+// which only commits to the hash of the batches L2 data
+func toValidiumBatchesData(sequences []ethmanTypes.Sequence) []polygonzkevm.PolygonValidiumEtrogValidiumBatchData {
 	var batches []polygonzkevm.PolygonValidiumEtrogValidiumBatchData
 	for _, seq := range sequences {
 		var ger common.Hash
@@ -194,66 +215,74 @@ func (etherMan *Client) sequenceBatches(opts bind.TransactOpts, sequences []ethm
 
 		batches = append(batches, batch)
 	}
-	tx, err := etherMan.ZkEVM.SequenceBatchesValidium(&opts, batches, maxSequenceTimestamp, lastSequencedBatchNumber, l2Coinbase, dataAvailabilityMessage)
-	if err != nil {
-		fmt.Println("sequenceBatches failed")
-	}
-	return tx, err
+	return batches
 }
 
-// LoadAuthFromKeyStoreXLayer loads an authorization from a key store file
-func (etherMan *Client) LoadAuthFromKeyStore(path, password string) (*bind.TransactOpts, *ecdsa.PrivateKey, error) {
-	auth, pk, err := newAuthFromKeystore(path, password, etherMan.l1Cfg.L1ChainID)
+// EstimateSequenceBatchesTxSize estimates the size of the sequenceBatchesValidium tx sending the sequences.
+// The calldata is packed without the contract binding, and the other tx fields are set to their widest
+// values, so the estimation is an upper bound of the size of the tx sent to L1.
+func EstimateSequenceBatchesTxSize(sequences []ethmanTypes.Sequence, maxSequenceTimestamp uint64, lastSequencedBatchNumber uint64, l2Coinbase common.Address, dataAvailabilityMessage []byte) (uint64, error) {
+	smcAbi, err := polygonzkevm.PolygonvalidiumXlayerMetaData.GetAbi()
 	if err != nil {
-		return nil, nil, err
+		return 0, err
 	}
-
-	fmt.Printf("loaded authorization for address: %v\n", auth.From.String())
-	etherMan.auth[auth.From] = auth
-	return &auth, pk, nil
-}
-
-// newAuthFromKeystore an authorization instance from a keystore file
-func newAuthFromKeystore(path, password string, chainID uint64) (bind.TransactOpts, *ecdsa.PrivateKey, error) {
-	fmt.Printf("reading key from: %v\n", path)
-	key, err := newKeyFromKeystore(path, password)
+	data, err := smcAbi.Pack("sequenceBatchesValidium", toValidiumBatchesData(sequences), maxSequenceTimestamp, lastSequencedBatchNumber, l2Coinbase, dataAvailabilityMessage)
 	if err != nil {
-		return bind.TransactOpts{}, nil, err
-	}
-	if key == nil {
-		return bind.TransactOpts{}, nil, nil
+		return 0, err
 	}
-	auth, err := bind.NewKeyedTransactorWithChainID(key.PrivateKey, new(big.Int).SetUint64(chainID))
-	if err != nil {
-		return bind.TransactOpts{}, nil, err
-	}
-	return *auth, key.PrivateKey, nil
+
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)) //nolint:gomnd
+	to := common.Address{}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   maxUint64,
+		Nonce:     math.MaxUint64,
+		GasTipCap: maxUint256,
+		GasFeeCap: maxUint256,
+		Gas:       math.MaxUint64,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      data,
+		V:         big.NewInt(1),
+		R:         maxUint256,
+		S:         maxUint256,
+	})
+	return tx.Size(), nil
 }
 
-// newKeyFromKeystore creates an instance of a keystore key from a keystore file
-func newKeyFromKeystore(path, password string) (*keystore.Key, error) {
-	if path == "" && password == "" {
-		return nil, nil
-	}
-	keystoreEncrypted, err := os.ReadFile(filepath.Clean(path))
-	if err != nil {
-		return nil, err
-	}
-	fmt.Printf("decrypting key from: %v\n", path)
-	key, err := keystore.DecryptKey(keystoreEncrypted, password)
+// LoadSigner creates the signer of the configuration and adds it to the authorizations
+// map. The keystore file is only used by the keystore signers.
+func (etherMan *Client) LoadSigner(cfg signer.Config, keystore configTypes.KeystoreFileConfig) (signer.Signer, error) {
+	s, err := signer.New(cfg, keystore, etherMan.l1Cfg.L1ChainID)
 	if err != nil {
 		return nil, err
 	}
-	return key, nil
+	etherMan.AddSigner(s)
+	return s, nil
+}
+
+// AddSigner adds the signer to the authorizations map
+func (etherMan *Client) AddSigner(s signer.Signer) {
+	fmt.Printf("loaded authorization for address: %v\n", s.Address().String())
+	etherMan.auth[s.Address()] = s
 }
 
 // GetAuthByAddress tries to get an authorization from the authorizations map
 func (etherMan *Client) GetAuthByAddress(addr common.Address) (bind.TransactOpts, error) {
-	auth, found := etherMan.auth[addr]
+	s, found := etherMan.auth[addr]
 	if !found {
 		return bind.TransactOpts{}, ErrNotFound
 	}
-	return auth, nil
+	return bind.TransactOpts{
+		From: addr,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != addr {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(context.Background(), tx)
+		},
+		Context: context.Background(),
+	}, nil
 }
 
 // generateRandomAuth generates an authorization instance from a
@@ -312,17 +341,13 @@ func (etherMan *Client) GetTxReceipt(ctx context.Context, txHash common.Hash) (*
 	return etherMan.EthClient.TransactionReceipt(ctx, txHash)
 }
 
-// GetL1GasPrice gets the l1 gas price
+// GetL1GasPrice gets the l1 gas price aggregated from the configured gas price sources,
+// or zero if none of them suggested a gas price
 func (etherMan *Client) GetL1GasPrice(ctx context.Context) *big.Int {
-	// Get gasPrice from providers
-	gasPrice := big.NewInt(0)
-	for i, prov := range etherMan.GasProviders.Providers {
-		gp, err := prov.SuggestGasPrice(ctx)
-		if err != nil {
-			fmt.Printf("error getting gas price from provider %d. Error: %s\n", i+1, err.Error())
-		} else if gasPrice.Cmp(gp) == -1 { // gasPrice < gp
-			gasPrice = gp
-		}
+	gasPrice, err := etherMan.GasOracle.SuggestGasPrice(ctx)
+	if err != nil {
+		fmt.Printf("error getting gas price. Error: %s\n", err.Error())
+		return big.NewInt(0)
 	}
 	fmt.Println("gasPrice chose: ", gasPrice)
 	return gasPrice
@@ -335,15 +360,11 @@ func (etherMan *Client) SendTx(ctx context.Context, tx *types.Transaction) error
 
 // SignTx tries to sign a transaction accordingly to the provided sender
 func (etherMan *Client) SignTx(ctx context.Context, sender common.Address, tx *types.Transaction) (*types.Transaction, error) {
-	auth, err := etherMan.GetAuthByAddress(sender)
-	if err == ErrNotFound {
+	s, found := etherMan.auth[sender]
+	if !found {
 		return nil, ErrNotFound
 	}
-	signedTx, err := auth.Signer(auth.From, tx)
-	if err != nil {
-		return nil, err
-	}
-	return signedTx, nil
+	return s.SignTx(ctx, tx)
 }
 
 // CurrentNonce returns the current nonce for the provided account
@@ -360,6 +381,26 @@ func (etherMan *Client) SuggestedGasPrice(ctx context.Context) (*big.Int, error)
 	return suggestedGasPrice, nil
 }
 
+// SuggestedGasTipCap returns the priority fee per gas suggested by the L1 node for the txs with a
+// dynamic fee
+func (etherMan *Client) SuggestedGasTipCap(ctx context.Context) (*big.Int, error) {
+	return etherMan.EthClient.SuggestGasTipCap(ctx)
+}
+
+// SuggestedBlobGasPrice returns the blob gas price for the next block, computed from the
+// excess blob gas of the latest block header
+func (etherMan *Client) SuggestedBlobGasPrice(ctx context.Context) (*big.Int, error) {
+	header, err := etherMan.EthClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.ExcessBlobGas == nil || header.BlobGasUsed == nil {
+		return nil, errors.New("latest L1 block does not support blob txs")
+	}
+	excessBlobGas := eip4844.CalcExcessBlobGas(*header.ExcessBlobGas, *header.BlobGasUsed)
+	return eip4844.CalcBlobFee(excessBlobGas), nil
+}
+
 // Get current balance at latest known block
 func (etherMan *Client) BalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
 	return etherMan.EthClient.BalanceAt(ctx, account, nil)
@@ -393,18 +434,69 @@ func (etherMan *Client) GetDAProtocolAddr() (common.Address, error) {
 
 // GetDAProtocolName returns the name of the data availability protocol
 func (etherMan *Client) GetDAProtocolName() (string, error) {
-	return etherMan.DAProtocol.GetProcotolName(&bind.CallOpts{Pending: false})
+	dapAddr, err := etherMan.GetDAProtocolAddr()
+	if err != nil {
+		return "", err
+	}
+	dap, err := dataavailabilityprotocol.NewDataavailabilityprotocol(dapAddr, etherMan.EthClient)
+	if err != nil {
+		return "", err
+	}
+	return dap.GetProcotolName(&bind.CallOpts{Pending: false})
 }
 
-// SetDataAvailabilityProtocol sets the address for the new data availability protocol
-func (etherMan *Client) SetDataAvailabilityProtocol(from, daAddress common.Address) (*types.Transaction, error) {
-	auth, err := etherMan.GetAuthByAddress(from)
+// IsSequenceWithDataAvailabilityAllowed returns whether the trusted sequencer is allowed to
+// sequence batches with their data in the calldata, skipping the data availability protocol
+func (etherMan *Client) IsSequenceWithDataAvailabilityAllowed() (bool, error) {
+	return etherMan.ZkEVM.IsSequenceWithDataAvailabilityAllowed(&bind.CallOpts{Pending: false})
+}
+
+// SetDataAvailabilityProtocol sets the address for the new data availability protocol. On a dry
+// run the tx is only built with its estimated gas, the sender key is not needed.
+func (etherMan *Client) SetDataAvailabilityProtocol(from, daAddress common.Address, dryRun bool) (*types.Transaction, error) {
+	auth, err := etherMan.getAdminAuth(from, dryRun)
 	if err != nil {
 		return nil, err
 	}
 	return etherMan.ZkEVM.SetDataAvailabilityProtocol(&auth, daAddress)
 }
 
+// SwitchSequenceWithDataAvailability allows or forbids the trusted sequencer to sequence batches
+// with their data in the calldata. On a dry run the tx is only built with its estimated gas.
+func (etherMan *Client) SwitchSequenceWithDataAvailability(from common.Address, allowed bool, dryRun bool) (*types.Transaction, error) {
+	auth, err := etherMan.getAdminAuth(from, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	return etherMan.ZkEVM.SwitchSequenceWithDataAvailability(&auth, allowed)
+}
+
+// DeployNubitDAVerifier deploys the NubitDA verifier data availability protocol, checking the data
+// availability messages are signed by the sequencer. The sender becomes the owner of the contract.
+// On a dry run the tx is only built with its estimated gas.
+func (etherMan *Client) DeployNubitDAVerifier(from, sequencer common.Address, dryRun bool) (common.Address, *types.Transaction, error) {
+	auth, err := etherMan.getAdminAuth(from, dryRun)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	address, tx, _, err := nubitdaverifier.DeployNubitdaverifier(&auth, etherMan.EthClient, sequencer)
+	return address, tx, err
+}
+
+// getAdminAuth returns the authorization of an admin tx sender. On a dry run the txs are signed
+// with a mock key and not sent, so only their estimated gas is meaningful.
+func (etherMan *Client) getAdminAuth(from common.Address, dryRun bool) (bind.TransactOpts, error) {
+	if !dryRun {
+		return etherMan.GetAuthByAddress(from)
+	}
+	auth, err := etherMan.generateMockAuth(from)
+	if err != nil {
+		return bind.TransactOpts{}, err
+	}
+	auth.NoSend = true
+	return auth, nil
+}
+
 // GetTrustedSequencerURL Gets the trusted sequencer url from rollup smc
 func (etherMan *Client) GetTrustedSequencerURL() (string, error) {
 	url, err := etherMan.ZkEVM.TrustedSequencerURL(&bind.CallOpts{Pending: false})
@@ -516,22 +608,9 @@ func (etherMan *Client) sequencedBatchesEvent(ctx context.Context, vLog types.Lo
 
 	var sequences []ethmanTypes.SequencedBatch
 	if sb.NumBatch != 1 {
-		methodId := tx.Data()[:4]
-		log.Debugf("MethodId: %s", common.Bytes2Hex(methodId))
-		if bytes.Equal(methodId, methodIDSequenceBatchesEtrog) ||
-			bytes.Equal(methodId, methodIDSequenceBatchesValidiumEtrog) {
-			sequences, err = decodeSequencesEtrog(tx.Data(), sb.NumBatch, msg.From, vLog.TxHash, msg.Nonce, sb.L1InfoRoot, etherMan.da)
-			if err != nil {
-				return nil, fmt.Errorf("error decoding the sequences (etrog): %v", err)
-			}
-		} else if bytes.Equal(methodId, methodIDSequenceBatchesElderberry) ||
-			bytes.Equal(methodId, methodIDSequenceBatchesValidiumElderberry) {
-			sequences, err = decodeSequencesElderberry(tx.Data(), sb.NumBatch, msg.From, vLog.TxHash, msg.Nonce, sb.L1InfoRoot, etherMan.da)
-			if err != nil {
-				return nil, fmt.Errorf("error decoding the sequences (elderberry): %v", err)
-			}
-		} else {
-			return nil, fmt.Errorf("error decoding the sequences: methodId %s unknown", common.Bytes2Hex(methodId))
+		sequences, err = DecodeSequencedBatches(tx.Data(), sb.NumBatch, msg.From, vLog.TxHash, msg.Nonce, sb.L1InfoRoot, etherMan.da)
+		if err != nil {
+			return nil, err
 		}
 	} else {
 		log.Info("initial transaction sequence...")
@@ -583,6 +662,266 @@ func RevertReason(ctx context.Context, c ethereumClient, tx *types.Transaction,
 	return unpackedMsg, nil
 }
 
+// DecodeSequencedBatches decodes the calldata of a sequenceBatches or sequenceBatchesValidium tx of any
+// supported fork, retrieving the data of the validium batches from the data provider
+func DecodeSequencedBatches(txData []byte, lastBatchNumber uint64, sequencer common.Address, txHash common.Hash, nonce uint64, l1InfoRoot common.Hash,
+	da dataavailability.BatchDataProvider) ([]ethmanTypes.SequencedBatch, error) {
+	if len(txData) < 4 { //nolint:gomnd
+		return nil, fmt.Errorf("error decoding the sequences: tx data too short")
+	}
+	methodId := txData[:4]
+	log.Debugf("MethodId: %s", common.Bytes2Hex(methodId))
+	if bytes.Equal(methodId, methodIDSequenceBatchesEtrog) ||
+		bytes.Equal(methodId, methodIDSequenceBatchesValidiumEtrog) {
+		sequences, err := decodeSequencesEtrog(txData, lastBatchNumber, sequencer, txHash, nonce, l1InfoRoot, da)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding the sequences (etrog): %v", err)
+		}
+		return sequences, nil
+	} else if bytes.Equal(methodId, methodIDSequenceBatchesElderberry) ||
+		bytes.Equal(methodId, methodIDSequenceBatchesValidiumElderberry) {
+		sequences, err := decodeSequencesElderberry(txData, lastBatchNumber, sequencer, txHash, nonce, l1InfoRoot, da)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding the sequences (elderberry): %v", err)
+		}
+		return sequences, nil
+	}
+	return nil, fmt.Errorf("error decoding the sequences: methodId %s unknown", common.Bytes2Hex(methodId))
+}
+
+// GetSequencedBatchesEvent returns the number of the last batch and the L1 info root of the
+// SequenceBatches event emitted by the tx
+func (etherMan *Client) GetSequencedBatchesEvent(ctx context.Context, txHash common.Hash) (uint64, common.Hash, error) {
+	receipt, err := etherMan.EthClient.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	// the event is parsed without the contract binding, which is not set up by the mock node
+	filterer, err := polygonzkevm.NewPolygonvalidiumXlayerFilterer(common.Address{}, nil)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	for _, vLog := range receipt.Logs {
+		if len(vLog.Topics) == 0 || vLog.Topics[0] != sequenceBatchesSignatureHash {
+			continue
+		}
+		sb, err := filterer.ParseSequenceBatches(*vLog)
+		if err != nil {
+			return 0, common.Hash{}, err
+		}
+		return sb.NumBatch, sb.L1InfoRoot, nil
+	}
+	return 0, common.Hash{}, ErrNotFound
+}
+
+// SequencedBatchesEvent is a SequenceBatches event emitted by the rollup contract
+type SequencedBatchesEvent struct {
+	// BatchNumber is the number of the last batch of the sequence
+	BatchNumber uint64
+	L1InfoRoot  common.Hash
+	TxHash      common.Hash
+	BlockNumber uint64
+}
+
+// GetLastSequencedBatch scans the SequenceBatches events emitted by the rollup contract from the
+// block on, and returns the event of the highest sequenced batch, or ErrNotFound if no batches were
+// sequenced since the block. The blocks are scanned in ranges accepted by most L1 nodes.
+func (etherMan *Client) GetLastSequencedBatch(ctx context.Context, fromBlock uint64) (SequencedBatchesEvent, error) {
+	if etherMan.l1Cfg.ZkEVMAddr == (common.Address{}) {
+		return SequencedBatchesEvent{}, errors.New("rollup contract address not configured")
+	}
+	header, err := etherMan.EthClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return SequencedBatchesEvent{}, err
+	}
+	latestBlock := header.Number.Uint64()
+
+	filterer, err := polygonzkevm.NewPolygonvalidiumXlayerFilterer(etherMan.l1Cfg.ZkEVMAddr, nil)
+	if err != nil {
+		return SequencedBatchesEvent{}, err
+	}
+	var (
+		last  SequencedBatchesEvent
+		found bool
+	)
+	for from := fromBlock; from <= latestBlock; from += maxEventsBlockRange {
+		to := from + maxEventsBlockRange - 1
+		if to > latestBlock {
+			to = latestBlock
+		}
+		logs, err := etherMan.EthClient.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{etherMan.l1Cfg.ZkEVMAddr},
+			Topics:    [][]common.Hash{{sequenceBatchesSignatureHash}},
+		})
+		if err != nil {
+			return SequencedBatchesEvent{}, fmt.Errorf("failed to filter SequenceBatches events from block %d to %d: %w", from, to, err)
+		}
+		for _, vLog := range logs {
+			sb, err := filterer.ParseSequenceBatches(vLog)
+			if err != nil {
+				return SequencedBatchesEvent{}, err
+			}
+			if !found || sb.NumBatch >= last.BatchNumber {
+				last = SequencedBatchesEvent{
+					BatchNumber: sb.NumBatch,
+					L1InfoRoot:  sb.L1InfoRoot,
+					TxHash:      vLog.TxHash,
+					BlockNumber: vLog.BlockNumber,
+				}
+				found = true
+			}
+		}
+	}
+	if !found {
+		return SequencedBatchesEvent{}, ErrNotFound
+	}
+	return last, nil
+}
+
+// GetForcedBatchFee returns the POL amount to pay to force a batch
+func (etherMan *Client) GetForcedBatchFee() (*big.Int, error) {
+	return etherMan.RollupManager.GetForcedBatchFee(&bind.CallOpts{Pending: false})
+}
+
+// ForceBatch forces a batch with the transactions in the rollup, paying up to polAmount POL. The
+// sender must be the force batch address of the rollup, unless forcing batches is open to anyone.
+func (etherMan *Client) ForceBatch(from common.Address, transactions []byte, polAmount *big.Int) (*types.Transaction, error) {
+	auth, err := etherMan.GetAuthByAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	return etherMan.ZkEVM.ForceBatch(&auth, transactions, polAmount)
+}
+
+// SequenceForceBatches sequences the forced batches without the trusted sequencer, once their
+// force batch timeout expired
+func (etherMan *Client) SequenceForceBatches(from common.Address, sequences []ethmanTypes.Sequence) (*types.Transaction, error) {
+	auth, err := etherMan.GetAuthByAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]polygonzkevm.PolygonRollupBaseEtrogBatchData, 0, len(sequences))
+	for _, seq := range sequences {
+		batches = append(batches, polygonzkevm.PolygonRollupBaseEtrogBatchData{
+			Transactions:         seq.BatchL2Data,
+			ForcedGlobalExitRoot: seq.GlobalExitRoot,
+			ForcedTimestamp:      uint64(seq.ForcedBatchTimestamp),
+			ForcedBlockHashL1:    seq.PrevBlockHash,
+		})
+	}
+	return etherMan.ZkEVM.SequenceForceBatches(&auth, batches)
+}
+
+// GetLastForceBatch returns the number of the last batch forced in the rollup
+func (etherMan *Client) GetLastForceBatch() (uint64, error) {
+	return etherMan.ZkEVM.LastForceBatch(&bind.CallOpts{Pending: false})
+}
+
+// GetLastForceBatchSequenced returns the number of the last forced batch sequenced in the rollup
+func (etherMan *Client) GetLastForceBatchSequenced() (uint64, error) {
+	return etherMan.ZkEVM.LastForceBatchSequenced(&bind.CallOpts{Pending: false})
+}
+
+// GetForcedBatchHash returns the hash of the forced batch data stored by the rollup, which is
+// deleted once the forced batch is sequenced
+func (etherMan *Client) GetForcedBatchHash(forcedBatchNumber uint64) (common.Hash, error) {
+	return etherMan.ZkEVM.ForcedBatches(&bind.CallOpts{Pending: false}, forcedBatchNumber)
+}
+
+// GetForcedBatches scans the ForceBatch events emitted by the rollup contract from the block on,
+// and returns the forced batches along with the last block scanned. The transactions forced by an
+// EOA are not in the event, so they are read from the calldata of the forceBatch tx.
+func (etherMan *Client) GetForcedBatches(ctx context.Context, fromBlock uint64) ([]ethmanTypes.ForcedBatch, uint64, error) {
+	if etherMan.l1Cfg.ZkEVMAddr == (common.Address{}) {
+		return nil, 0, errors.New("rollup contract address not configured")
+	}
+	header, err := etherMan.EthClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	latestBlock := header.Number.Uint64()
+
+	filterer, err := polygonzkevm.NewPolygonvalidiumXlayerFilterer(etherMan.l1Cfg.ZkEVMAddr, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	var forcedBatches []ethmanTypes.ForcedBatch
+	for from := fromBlock; from <= latestBlock; from += maxEventsBlockRange {
+		to := from + maxEventsBlockRange - 1
+		if to > latestBlock {
+			to = latestBlock
+		}
+		logs, err := etherMan.EthClient.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{etherMan.l1Cfg.ZkEVMAddr},
+			Topics:    [][]common.Hash{{forceBatchSignatureHash}},
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to filter ForceBatch events from block %d to %d: %w", from, to, err)
+		}
+		for _, vLog := range logs {
+			fb, err := filterer.ParseForceBatch(vLog)
+			if err != nil {
+				return nil, 0, err
+			}
+			forcedBatch, err := etherMan.forcedBatchEvent(ctx, fb)
+			if err != nil {
+				return nil, 0, err
+			}
+			forcedBatches = append(forcedBatches, forcedBatch)
+		}
+	}
+	return forcedBatches, latestBlock, nil
+}
+
+// forcedBatchEvent completes the ForceBatch event with the L1 block of the forceBatch tx, and its
+// calldata when the forced transactions are not in the event
+func (etherMan *Client) forcedBatchEvent(ctx context.Context, fb *polygonzkevm.PolygonvalidiumXlayerForceBatch) (ethmanTypes.ForcedBatch, error) {
+	header, err := etherMan.EthClient.HeaderByHash(ctx, fb.Raw.BlockHash)
+	if err != nil {
+		return ethmanTypes.ForcedBatch{}, fmt.Errorf("failed to get block %v of forced batch %d: %w", fb.Raw.BlockHash, fb.ForceBatchNum, err)
+	}
+	forcedBatch := ethmanTypes.ForcedBatch{
+		ForcedBatchNumber: fb.ForceBatchNum,
+		GlobalExitRoot:    fb.LastGlobalExitRoot,
+		ForcedAt:          time.Unix(int64(header.Time), 0),
+		PrevBlockHash:     header.ParentHash,
+		Sequencer:         fb.Sequencer,
+		Transactions:      fb.Transactions,
+		BlockNumber:       fb.Raw.BlockNumber,
+		TxHash:            fb.Raw.TxHash,
+	}
+	if len(fb.Transactions) > 0 {
+		return forcedBatch, nil
+	}
+
+	tx, err := etherMan.EthClient.TransactionInBlock(ctx, fb.Raw.BlockHash, fb.Raw.TxIndex)
+	if err != nil {
+		return ethmanTypes.ForcedBatch{}, err
+	}
+	if tx.Hash() != fb.Raw.TxHash {
+		return ethmanTypes.ForcedBatch{}, fmt.Errorf("error: tx hash mismatch. want: %s have: %s", fb.Raw.TxHash, tx.Hash().String())
+	}
+	smcAbi, err := polygonzkevm.PolygonvalidiumXlayerMetaData.GetAbi()
+	if err != nil {
+		return ethmanTypes.ForcedBatch{}, err
+	}
+	method := smcAbi.Methods["forceBatch"]
+	if len(tx.Data()) < 4 || !bytes.Equal(tx.Data()[:4], method.ID) { //nolint:gomnd
+		// forced by a contract with empty transactions
+		return forcedBatch, nil
+	}
+	data, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return ethmanTypes.ForcedBatch{}, fmt.Errorf("failed to decode forceBatch tx %v: %w", tx.Hash(), err)
+	}
+	forcedBatch.Transactions = data[0].([]byte)
+	return forcedBatch, nil
+}
+
 func decodeSequencesElderberry(txData []byte, lastBatchNumber uint64, sequencer common.Address, txHash common.Hash, nonce uint64, l1InfoRoot common.Hash, da dataavailability.BatchDataProvider) ([]ethmanTypes.SequencedBatch, error) {
 	// Extract coded txs.
 	// Load contract ABI
@@ -0,0 +1,52 @@
+package etherman
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	polygonzkevm "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonvalidium_xlayer"
+	ethmanTypes "github.com/sieniven/zkevm-nubit/etherman/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateSequenceBatchesTxSize(t *testing.T) {
+	sequences := []ethmanTypes.Sequence{
+		{BatchNumber: 1, BatchL2Data: []byte{1, 2, 3}},
+		{BatchNumber: 2, BatchL2Data: []byte{4}, ForcedBatchTimestamp: 10, GlobalExitRoot: common.HexToHash("0x1")},
+	}
+	coinbase := common.HexToAddress("0xc0")
+	daMessage := make([]byte, 200)
+
+	estimated, err := EstimateSequenceBatchesTxSize(sequences, 100, 0, coinbase, daMessage)
+	require.NoError(t, err)
+
+	// The estimation is an upper bound of the size of the signed tx
+	smcAbi, err := polygonzkevm.PolygonvalidiumXlayerMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := smcAbi.Pack("sequenceBatchesValidium", toValidiumBatchesData(sequences), uint64(100), uint64(0), coinbase, daMessage)
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x519E42c24163192Dca44CD3fBDCEBF6be9130987")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(11155111)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(11155111),
+		Nonce:     123456,
+		GasTipCap: big.NewInt(2_000_000_000),
+		GasFeeCap: big.NewInt(300_000_000_000),
+		Gas:       1_000_000,
+		To:        &to,
+		Data:      data,
+	})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, tx.Size(), estimated)
+	assert.Less(t, estimated-tx.Size(), uint64(150))
+
+	// Every batch adds its hash, forced global exit root, forced timestamp and forced block hash
+	more, err := EstimateSequenceBatchesTxSize(append(sequences, ethmanTypes.Sequence{BatchNumber: 3}), 100, 0, coinbase, daMessage)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4*32), more-estimated)
+}
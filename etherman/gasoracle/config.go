@@ -0,0 +1,79 @@
+package gasoracle
+
+import (
+	"time"
+
+	"github.com/sieniven/zkevm-nubit/config/types"
+)
+
+// SourceType is the type of a gas price source
+type SourceType string
+
+const (
+	// SourceTypeNode suggests the gas price of the L1 node
+	SourceTypeNode SourceType = "node"
+	// SourceTypeFeeHistory estimates the gas price from a percentile of the priority fees
+	// paid in the latest blocks, on top of the base fee of the next block
+	SourceTypeFeeHistory SourceType = "feehistory"
+	// SourceTypeFixed always suggests the same gas price
+	SourceTypeFixed SourceType = "fixed"
+	// SourceTypeHTTP queries the gas price from an HTTP oracle
+	SourceTypeHTTP SourceType = "http"
+)
+
+// AggregationType is the strategy used to aggregate the gas prices of all the sources
+type AggregationType string
+
+const (
+	// AggregationMax picks the highest gas price
+	AggregationMax AggregationType = "max"
+	// AggregationMedian picks the median of the gas prices
+	AggregationMedian AggregationType = "median"
+	// AggregationWeighted picks the average of the gas prices weighted by the source weights
+	AggregationWeighted AggregationType = "weighted"
+)
+
+const (
+	// DefaultSourceTimeout is the timeout of a source that has no timeout configured
+	DefaultSourceTimeout = 5 * time.Second
+	// DefaultFeeHistoryBlocks is the number of blocks considered by a fee history source
+	// that has no block count configured
+	DefaultFeeHistoryBlocks = 20
+	// DefaultFeeHistoryPercentile is the percentile of the priority fees considered by a
+	// fee history source that has no percentile configured
+	DefaultFeeHistoryPercentile = 60
+	// DefaultHTTPField is the JSON field holding the gas price in the HTTP oracle responses
+	DefaultHTTPField = "gasPrice"
+)
+
+// Config represents the configuration of the gas price sources
+type Config struct {
+	// Aggregation is the strategy used to aggregate the gas prices of the sources,
+	// max by default
+	Aggregation AggregationType `mapstructure:"Aggregation"`
+	// Sources are the gas price sources. The L1 node is used if none is configured
+	Sources []SourceConfig `mapstructure:"Sources"`
+}
+
+// SourceConfig represents the configuration of a gas price source
+type SourceConfig struct {
+	// Type is the type of the source
+	Type SourceType `mapstructure:"Type"`
+	// Timeout is the time to wait for the gas price of the source before ignoring it
+	Timeout types.Duration `mapstructure:"Timeout"`
+	// Weight is the weight of the source for the weighted aggregation, 1 by default
+	Weight uint64 `mapstructure:"Weight"`
+
+	// GasPrice is the gas price in wei suggested by a fixed source
+	GasPrice uint64 `mapstructure:"GasPrice"`
+
+	// Blocks is the number of latest blocks considered by a fee history source
+	Blocks uint64 `mapstructure:"Blocks"`
+	// Percentile is the percentile of the priority fees considered by a fee history source
+	Percentile float64 `mapstructure:"Percentile"`
+
+	// URL is the URL queried by an HTTP source
+	URL string `mapstructure:"URL"`
+	// Field is the JSON field holding the gas price in wei in the responses of an HTTP source
+	Field string `mapstructure:"Field"`
+}
@@ -0,0 +1,184 @@
+// Package gasoracle aggregates the gas prices suggested by several pluggable sources,
+// such as the L1 node, a fee history estimator, a fixed value or an HTTP oracle.
+package gasoracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/sieniven/zkevm-nubit/log"
+)
+
+// NodeClient is the L1 node client used by the node and fee history sources
+type NodeClient interface {
+	ethereum.GasPricer
+	ethereum.FeeHistoryReader
+}
+
+// source is a gas price source with its settings
+type source struct {
+	name    string
+	pricer  ethereum.GasPricer
+	timeout time.Duration
+	weight  uint64
+}
+
+// GasOracle suggests the gas price aggregated from all its sources. The sources are
+// queried in parallel, and the ones failing or not answering in time are ignored.
+type GasOracle struct {
+	aggregation AggregationType
+	sources     []source
+}
+
+// New creates a gas oracle with the sources of the configuration. The node client backs
+// the node and fee history sources, and it is the only source if none is configured.
+func New(cfg Config, node NodeClient) (*GasOracle, error) {
+	aggregation := cfg.Aggregation
+	switch aggregation {
+	case "":
+		aggregation = AggregationMax
+	case AggregationMax, AggregationMedian, AggregationWeighted:
+	default:
+		return nil, fmt.Errorf("unsupported gas price aggregation: %s", aggregation)
+	}
+
+	sourceCfgs := cfg.Sources
+	if len(sourceCfgs) == 0 {
+		sourceCfgs = []SourceConfig{{Type: SourceTypeNode}}
+	}
+
+	sources := make([]source, 0, len(sourceCfgs))
+	for i, sourceCfg := range sourceCfgs {
+		pricer, err := newGasPricer(sourceCfg, node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas price source %d: %w", i+1, err)
+		}
+		s := source{
+			name:    fmt.Sprintf("%d (%s)", i+1, sourceCfg.Type),
+			pricer:  pricer,
+			timeout: sourceCfg.Timeout.Duration,
+			weight:  sourceCfg.Weight,
+		}
+		if s.timeout == 0 {
+			s.timeout = DefaultSourceTimeout
+		}
+		if s.weight == 0 {
+			s.weight = 1
+		}
+		sources = append(sources, s)
+	}
+
+	return &GasOracle{
+		aggregation: aggregation,
+		sources:     sources,
+	}, nil
+}
+
+// newGasPricer creates the gas pricer of the source configuration
+func newGasPricer(cfg SourceConfig, node NodeClient) (ethereum.GasPricer, error) {
+	switch cfg.Type {
+	case SourceTypeNode:
+		return node, nil
+	case SourceTypeFeeHistory:
+		blocks := cfg.Blocks
+		if blocks == 0 {
+			blocks = DefaultFeeHistoryBlocks
+		}
+		percentile := cfg.Percentile
+		if percentile == 0 {
+			percentile = DefaultFeeHistoryPercentile
+		}
+		if percentile < 0 || percentile > 100 {
+			return nil, fmt.Errorf("invalid fee history percentile: %v", percentile)
+		}
+		return NewFeeHistoryGasPricer(node, blocks, percentile), nil
+	case SourceTypeFixed:
+		if cfg.GasPrice == 0 {
+			return nil, errors.New("fixed gas price not configured")
+		}
+		return NewFixedGasPricer(new(big.Int).SetUint64(cfg.GasPrice)), nil
+	case SourceTypeHTTP:
+		if cfg.URL == "" {
+			return nil, errors.New("http gas price oracle URL not configured")
+		}
+		field := cfg.Field
+		if field == "" {
+			field = DefaultHTTPField
+		}
+		return NewHTTPGasPricer(cfg.URL, field), nil
+	default:
+		return nil, fmt.Errorf("unsupported gas price source type: %s", cfg.Type)
+	}
+}
+
+// weightedGasPrice is the gas price suggested by a source with the source weight
+type weightedGasPrice struct {
+	gasPrice *big.Int
+	weight   uint64
+}
+
+// SuggestGasPrice returns the gas price aggregated from the gas prices of the sources
+func (o *GasOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	gasPrices := make([]*weightedGasPrice, len(o.sources))
+	wg := sync.WaitGroup{}
+	wg.Add(len(o.sources))
+	for i, s := range o.sources {
+		go func(i int, s source) {
+			defer wg.Done()
+			sourceCtx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+			gasPrice, err := s.pricer.SuggestGasPrice(sourceCtx)
+			if err != nil {
+				log.Warnf("error getting gas price from source %s. Error: %s", s.name, err.Error())
+				return
+			}
+			gasPrices[i] = &weightedGasPrice{gasPrice: gasPrice, weight: s.weight}
+		}(i, s)
+	}
+	wg.Wait()
+
+	suggested := make([]weightedGasPrice, 0, len(gasPrices))
+	for _, gasPrice := range gasPrices {
+		if gasPrice != nil {
+			suggested = append(suggested, *gasPrice)
+		}
+	}
+	if len(suggested) == 0 {
+		return nil, errors.New("no gas price source suggested a gas price")
+	}
+	return aggregate(o.aggregation, suggested), nil
+}
+
+// aggregate aggregates the gas prices with the strategy
+func aggregate(aggregation AggregationType, gasPrices []weightedGasPrice) *big.Int {
+	switch aggregation {
+	case AggregationMedian:
+		values := make([]*big.Int, 0, len(gasPrices))
+		for _, gp := range gasPrices {
+			values = append(values, gp.gasPrice)
+		}
+		return median(values)
+	case AggregationWeighted:
+		sum := big.NewInt(0)
+		totalWeight := big.NewInt(0)
+		for _, gp := range gasPrices {
+			weight := new(big.Int).SetUint64(gp.weight)
+			sum.Add(sum, new(big.Int).Mul(gp.gasPrice, weight))
+			totalWeight.Add(totalWeight, weight)
+		}
+		return sum.Div(sum, totalWeight)
+	default:
+		gasPrice := big.NewInt(0)
+		for _, gp := range gasPrices {
+			if gasPrice.Cmp(gp.gasPrice) == -1 {
+				gasPrice = gp.gasPrice
+			}
+		}
+		return new(big.Int).Set(gasPrice)
+	}
+}
@@ -0,0 +1,179 @@
+package gasoracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nodeClientMock struct {
+	gasPrice   *big.Int
+	err        error
+	delay      time.Duration
+	feeHistory *ethereum.FeeHistory
+}
+
+func (n *nodeClientMock) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	select {
+	case <-time.After(n.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return n.gasPrice, n.err
+}
+
+func (n *nodeClientMock) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return n.feeHistory, nil
+}
+
+func TestDefaultSourceIsNode(t *testing.T) {
+	oracle, err := New(Config{}, &nodeClientMock{gasPrice: big.NewInt(7)})
+	require.NoError(t, err)
+
+	gasPrice, err := oracle.SuggestGasPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(7), gasPrice)
+}
+
+func TestAggregation(t *testing.T) {
+	sources := []SourceConfig{
+		{Type: SourceTypeNode, Weight: 2},
+		{Type: SourceTypeFixed, GasPrice: 10, Weight: 1},
+		{Type: SourceTypeFixed, GasPrice: 40, Weight: 1},
+		{Type: SourceTypeFixed, GasPrice: 30, Weight: 1},
+	}
+	tcs := []struct {
+		aggregation AggregationType
+		expected    int64
+	}{
+		{aggregation: "", expected: 40},
+		{aggregation: AggregationMax, expected: 40},
+		{aggregation: AggregationMedian, expected: 25},
+		// (20*2 + 10 + 40 + 30) / 5
+		{aggregation: AggregationWeighted, expected: 24},
+	}
+	for _, tc := range tcs {
+		t.Run(string(tc.aggregation), func(t *testing.T) {
+			oracle, err := New(Config{Aggregation: tc.aggregation, Sources: sources}, &nodeClientMock{gasPrice: big.NewInt(20)})
+			require.NoError(t, err)
+
+			gasPrice, err := oracle.SuggestGasPrice(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, big.NewInt(tc.expected), gasPrice)
+		})
+	}
+}
+
+func TestFailingAndSlowSourcesAreIgnored(t *testing.T) {
+	cfg := Config{
+		Aggregation: AggregationMax,
+		Sources: []SourceConfig{
+			{Type: SourceTypeNode, Timeout: types.NewDuration(10 * time.Millisecond)},
+			{Type: SourceTypeFixed, GasPrice: 5},
+		},
+	}
+
+	// The node is too slow
+	oracle, err := New(cfg, &nodeClientMock{gasPrice: big.NewInt(100), delay: time.Second})
+	require.NoError(t, err)
+	start := time.Now()
+	gasPrice, err := oracle.SuggestGasPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), gasPrice)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The node fails
+	oracle, err = New(cfg, &nodeClientMock{err: errors.New("unavailable")})
+	require.NoError(t, err)
+	gasPrice, err = oracle.SuggestGasPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), gasPrice)
+
+	// No source suggests a gas price
+	oracle, err = New(Config{}, &nodeClientMock{err: errors.New("unavailable")})
+	require.NoError(t, err)
+	_, err = oracle.SuggestGasPrice(context.Background())
+	require.Error(t, err)
+}
+
+func TestFeeHistorySource(t *testing.T) {
+	node := &nodeClientMock{feeHistory: &ethereum.FeeHistory{
+		Reward:  [][]*big.Int{{big.NewInt(1)}, {big.NewInt(9)}, {big.NewInt(3)}},
+		BaseFee: []*big.Int{big.NewInt(50), big.NewInt(60), big.NewInt(70), big.NewInt(80)},
+	}}
+	oracle, err := New(Config{Sources: []SourceConfig{{Type: SourceTypeFeeHistory, Blocks: 3}}}, node)
+	require.NoError(t, err)
+
+	// base fee of the next block plus the median tip
+	gasPrice, err := oracle.SuggestGasPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(83), gasPrice)
+}
+
+func TestHTTPSource(t *testing.T) {
+	responses := map[string]string{
+		"/number":  `{"gasPrice": 1000000000}`,
+		"/decimal": `{"gasPrice": "2000000000"}`,
+		"/hex":     `{"fast": "0x3b9aca00"}`,
+		"/invalid": `{"gasPrice": "fast"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, found := responses[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	tcs := []struct {
+		path     string
+		field    string
+		expected *big.Int
+	}{
+		{path: "/number", expected: big.NewInt(1000000000)},
+		{path: "/decimal", expected: big.NewInt(2000000000)},
+		{path: "/hex", field: "fast", expected: big.NewInt(1000000000)},
+		{path: "/hex"},
+		{path: "/invalid"},
+		{path: "/missing"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.path+tc.field, func(t *testing.T) {
+			pricer, err := newGasPricer(SourceConfig{Type: SourceTypeHTTP, URL: server.URL + tc.path, Field: tc.field}, nil)
+			require.NoError(t, err)
+
+			gasPrice, err := pricer.SuggestGasPrice(context.Background())
+			if tc.expected == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, gasPrice)
+		})
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	invalid := []Config{
+		{Aggregation: "min"},
+		{Sources: []SourceConfig{{Type: "etherscan"}}},
+		{Sources: []SourceConfig{{Type: SourceTypeFixed}}},
+		{Sources: []SourceConfig{{Type: SourceTypeHTTP}}},
+		{Sources: []SourceConfig{{Type: SourceTypeFeeHistory, Percentile: 101}}},
+	}
+	for _, cfg := range invalid {
+		_, err := New(cfg, &nodeClientMock{})
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,149 @@
+package gasoracle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FixedGasPricer always suggests the same gas price
+type FixedGasPricer struct {
+	gasPrice *big.Int
+}
+
+// NewFixedGasPricer creates a gas pricer suggesting the provided gas price
+func NewFixedGasPricer(gasPrice *big.Int) *FixedGasPricer {
+	return &FixedGasPricer{gasPrice: gasPrice}
+}
+
+// SuggestGasPrice returns the fixed gas price
+func (f *FixedGasPricer) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(f.gasPrice), nil
+}
+
+// FeeHistoryGasPricer estimates the gas price as the base fee of the next block plus the
+// median across the latest blocks of the configured percentile of their priority fees
+type FeeHistoryGasPricer struct {
+	client     ethereum.FeeHistoryReader
+	blocks     uint64
+	percentile float64
+}
+
+// NewFeeHistoryGasPricer creates a gas pricer estimating the gas price from the fee history
+// of the provided number of latest blocks
+func NewFeeHistoryGasPricer(client ethereum.FeeHistoryReader, blocks uint64, percentile float64) *FeeHistoryGasPricer {
+	return &FeeHistoryGasPricer{
+		client:     client,
+		blocks:     blocks,
+		percentile: percentile,
+	}
+}
+
+// SuggestGasPrice returns the gas price estimated from the fee history
+func (f *FeeHistoryGasPricer) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	history, err := f.client.FeeHistory(ctx, f.blocks, nil, []float64{f.percentile})
+	if err != nil {
+		return nil, err
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, errors.New("fee history without base fees")
+	}
+
+	tips := make([]*big.Int, 0, len(history.Reward))
+	for _, reward := range history.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			tips = append(tips, reward[0])
+		}
+	}
+	tip := big.NewInt(0)
+	if len(tips) > 0 {
+		tip = median(tips)
+	}
+
+	// the last base fee is the one of the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	return new(big.Int).Add(baseFee, tip), nil
+}
+
+// HTTPGasPricer queries the gas price from an HTTP oracle returning a JSON object, which
+// holds the gas price in wei in the configured field as a number, a decimal string or a
+// hex string
+type HTTPGasPricer struct {
+	client *http.Client
+	url    string
+	field  string
+}
+
+// NewHTTPGasPricer creates a gas pricer querying the HTTP oracle at the provided URL
+func NewHTTPGasPricer(url, field string) *HTTPGasPricer {
+	return &HTTPGasPricer{
+		client: http.DefaultClient,
+		url:    url,
+		field:  field,
+	}
+}
+
+// SuggestGasPrice returns the gas price of the HTTP oracle
+func (h *HTTPGasPricer) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %v from %v", res.StatusCode, h.url)
+	}
+
+	var body map[string]json.RawMessage
+	decoder := json.NewDecoder(res.Body)
+	decoder.UseNumber()
+	err = decoder.Decode(&body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response from %v: %w", h.url, err)
+	}
+	raw, found := body[h.field]
+	if !found {
+		return nil, fmt.Errorf("field %v not found in response from %v", h.field, h.url)
+	}
+	return parseGasPrice(raw)
+}
+
+// parseGasPrice parses a gas price in wei from a JSON number, decimal string or hex string
+func parseGasPrice(raw json.RawMessage) (*big.Int, error) {
+	value := strings.Trim(string(raw), `"`)
+	if strings.HasPrefix(value, "0x") {
+		return hexutil.DecodeBig(value)
+	}
+	gasPrice, ok := new(big.Int).SetString(value, 10) //nolint:gomnd
+	if !ok {
+		return nil, fmt.Errorf("invalid gas price %v", string(raw))
+	}
+	return gasPrice, nil
+}
+
+// median returns the median of the values, the average of the two middle values for an
+// even number of values
+func median(values []*big.Int) *big.Int {
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) == -1 })
+
+	middle := len(sorted) / 2 //nolint:gomnd
+	if len(sorted)%2 == 1 {
+		return new(big.Int).Set(sorted[middle])
+	}
+	sum := new(big.Int).Add(sorted[middle-1], sorted[middle])
+	return sum.Div(sum, big.NewInt(2)) //nolint:gomnd
+}
@@ -0,0 +1,480 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package nubitdaverifier
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// NubitdaverifierMetaData contains all meta data concerning the Nubitdaverifier contract.
+var NubitdaverifierMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_sequencer\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"InvalidSignature\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSignatureLength\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"OnlyOwner\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"UnexpectedHash\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"newSequencer\",\"type\":\"address\"}],\"name\":\"SetSequencer\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"getProcotolName\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"sequencer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newSequencer\",\"type\":\"address\"}],\"name\":\"setSequencer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"hash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"dataAvailabilityMessage\",\"type\":\"bytes\"}],\"name\":\"verifyMessage\",\"outputs\":[],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x34630000004b576102a6630000005160010101806020013810630000004b576020906000396000518060a01c630000004b57600155336000556102a68063000000516001016000396000f35b60006000fd5b34630000004e5760003560e01c80633b51be4b14630000010e578063e4f1712014630000007d5780632547fa3e1463000000b15780638da5cb5b1463000000695780635c1bba38146300000073575b60006000fd5b60e01b60005260046000fd5b60005260206000f35b6000546300000060565b6001546300000060565b602060005260076020527f4e7562697444410000000000000000000000000000000000000000000000000060405260606000f35b60243610630000004e576004358060a01c630000004e57600054331463000000df57635fc483c56300000054565b806001556000527fb6463e90f47a47e83f57f1427fd5d5c33461ba411b3a0911d11ecd3979c2003960206000a1005b60443610630000004e576024358067ffffffffffffffff10630000004e57600401806020013610630000004e5780358067ffffffffffffffff10630000004e5790602001908101803610630000004e57816020018110630000004e5781358067ffffffffffffffff10630000004e578201806040018210630000004e5780358067ffffffffffffffff10630000004e578101806020018310630000004e5780358067ffffffffffffffff10630000004e5781602001018310630000004e575080602001358067ffffffffffffffff10630000004e578101806020018310630000004e5780358067ffffffffffffffff10630000004e5781602001018310630000004e578035606114630000022857634be6321b6300000054565b80602001356004351463000002435763cb2b3ac26300000054565b600435600052806080013560f81c60205280604001356040528060600135606052602060006080600060015afa15630000004e573d60201415630000029a576000518015630000029a576001541415630000029a57005b638baa579f630000005456",
+}
+
+// NubitdaverifierABI is the input ABI used to generate the binding from.
+// Deprecated: Use NubitdaverifierMetaData.ABI instead.
+var NubitdaverifierABI = NubitdaverifierMetaData.ABI
+
+// NubitdaverifierBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use NubitdaverifierMetaData.Bin instead.
+var NubitdaverifierBin = NubitdaverifierMetaData.Bin
+
+// DeployNubitdaverifier deploys a new Ethereum contract, binding an instance of Nubitdaverifier to it.
+func DeployNubitdaverifier(auth *bind.TransactOpts, backend bind.ContractBackend, _sequencer common.Address) (common.Address, *types.Transaction, *Nubitdaverifier, error) {
+	parsed, err := NubitdaverifierMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(NubitdaverifierBin), backend, _sequencer)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Nubitdaverifier{NubitdaverifierCaller: NubitdaverifierCaller{contract: contract}, NubitdaverifierTransactor: NubitdaverifierTransactor{contract: contract}, NubitdaverifierFilterer: NubitdaverifierFilterer{contract: contract}}, nil
+}
+
+// Nubitdaverifier is an auto generated Go binding around an Ethereum contract.
+type Nubitdaverifier struct {
+	NubitdaverifierCaller     // Read-only binding to the contract
+	NubitdaverifierTransactor // Write-only binding to the contract
+	NubitdaverifierFilterer   // Log filterer for contract events
+}
+
+// NubitdaverifierCaller is an auto generated read-only Go binding around an Ethereum contract.
+type NubitdaverifierCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NubitdaverifierTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type NubitdaverifierTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NubitdaverifierFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type NubitdaverifierFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NubitdaverifierSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type NubitdaverifierSession struct {
+	Contract     *Nubitdaverifier  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// NubitdaverifierCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type NubitdaverifierCallerSession struct {
+	Contract *NubitdaverifierCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// NubitdaverifierTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type NubitdaverifierTransactorSession struct {
+	Contract     *NubitdaverifierTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// NubitdaverifierRaw is an auto generated low-level Go binding around an Ethereum contract.
+type NubitdaverifierRaw struct {
+	Contract *Nubitdaverifier // Generic contract binding to access the raw methods on
+}
+
+// NubitdaverifierCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type NubitdaverifierCallerRaw struct {
+	Contract *NubitdaverifierCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// NubitdaverifierTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type NubitdaverifierTransactorRaw struct {
+	Contract *NubitdaverifierTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewNubitdaverifier creates a new instance of Nubitdaverifier, bound to a specific deployed contract.
+func NewNubitdaverifier(address common.Address, backend bind.ContractBackend) (*Nubitdaverifier, error) {
+	contract, err := bindNubitdaverifier(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Nubitdaverifier{NubitdaverifierCaller: NubitdaverifierCaller{contract: contract}, NubitdaverifierTransactor: NubitdaverifierTransactor{contract: contract}, NubitdaverifierFilterer: NubitdaverifierFilterer{contract: contract}}, nil
+}
+
+// NewNubitdaverifierCaller creates a new read-only instance of Nubitdaverifier, bound to a specific deployed contract.
+func NewNubitdaverifierCaller(address common.Address, caller bind.ContractCaller) (*NubitdaverifierCaller, error) {
+	contract, err := bindNubitdaverifier(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NubitdaverifierCaller{contract: contract}, nil
+}
+
+// NewNubitdaverifierTransactor creates a new write-only instance of Nubitdaverifier, bound to a specific deployed contract.
+func NewNubitdaverifierTransactor(address common.Address, transactor bind.ContractTransactor) (*NubitdaverifierTransactor, error) {
+	contract, err := bindNubitdaverifier(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NubitdaverifierTransactor{contract: contract}, nil
+}
+
+// NewNubitdaverifierFilterer creates a new log filterer instance of Nubitdaverifier, bound to a specific deployed contract.
+func NewNubitdaverifierFilterer(address common.Address, filterer bind.ContractFilterer) (*NubitdaverifierFilterer, error) {
+	contract, err := bindNubitdaverifier(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NubitdaverifierFilterer{contract: contract}, nil
+}
+
+// bindNubitdaverifier binds a generic wrapper to an already deployed contract.
+func bindNubitdaverifier(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := NubitdaverifierMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Nubitdaverifier *NubitdaverifierRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Nubitdaverifier.Contract.NubitdaverifierCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Nubitdaverifier *NubitdaverifierRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Nubitdaverifier.Contract.NubitdaverifierTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Nubitdaverifier *NubitdaverifierRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Nubitdaverifier.Contract.NubitdaverifierTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Nubitdaverifier *NubitdaverifierCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Nubitdaverifier.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Nubitdaverifier *NubitdaverifierTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Nubitdaverifier.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Nubitdaverifier *NubitdaverifierTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Nubitdaverifier.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetProcotolName is a free data retrieval call binding the contract method 0xe4f17120.
+//
+// Solidity: function getProcotolName() pure returns(string)
+func (_Nubitdaverifier *NubitdaverifierCaller) GetProcotolName(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _Nubitdaverifier.contract.Call(opts, &out, "getProcotolName")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// GetProcotolName is a free data retrieval call binding the contract method 0xe4f17120.
+//
+// Solidity: function getProcotolName() pure returns(string)
+func (_Nubitdaverifier *NubitdaverifierSession) GetProcotolName() (string, error) {
+	return _Nubitdaverifier.Contract.GetProcotolName(&_Nubitdaverifier.CallOpts)
+}
+
+// GetProcotolName is a free data retrieval call binding the contract method 0xe4f17120.
+//
+// Solidity: function getProcotolName() pure returns(string)
+func (_Nubitdaverifier *NubitdaverifierCallerSession) GetProcotolName() (string, error) {
+	return _Nubitdaverifier.Contract.GetProcotolName(&_Nubitdaverifier.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Nubitdaverifier *NubitdaverifierCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Nubitdaverifier.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Nubitdaverifier *NubitdaverifierSession) Owner() (common.Address, error) {
+	return _Nubitdaverifier.Contract.Owner(&_Nubitdaverifier.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Nubitdaverifier *NubitdaverifierCallerSession) Owner() (common.Address, error) {
+	return _Nubitdaverifier.Contract.Owner(&_Nubitdaverifier.CallOpts)
+}
+
+// Sequencer is a free data retrieval call binding the contract method 0x5c1bba38.
+//
+// Solidity: function sequencer() view returns(address)
+func (_Nubitdaverifier *NubitdaverifierCaller) Sequencer(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Nubitdaverifier.contract.Call(opts, &out, "sequencer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Sequencer is a free data retrieval call binding the contract method 0x5c1bba38.
+//
+// Solidity: function sequencer() view returns(address)
+func (_Nubitdaverifier *NubitdaverifierSession) Sequencer() (common.Address, error) {
+	return _Nubitdaverifier.Contract.Sequencer(&_Nubitdaverifier.CallOpts)
+}
+
+// Sequencer is a free data retrieval call binding the contract method 0x5c1bba38.
+//
+// Solidity: function sequencer() view returns(address)
+func (_Nubitdaverifier *NubitdaverifierCallerSession) Sequencer() (common.Address, error) {
+	return _Nubitdaverifier.Contract.Sequencer(&_Nubitdaverifier.CallOpts)
+}
+
+// VerifyMessage is a free data retrieval call binding the contract method 0x3b51be4b.
+//
+// Solidity: function verifyMessage(bytes32 hash, bytes dataAvailabilityMessage) view returns()
+func (_Nubitdaverifier *NubitdaverifierCaller) VerifyMessage(opts *bind.CallOpts, hash [32]byte, dataAvailabilityMessage []byte) error {
+	var out []interface{}
+	err := _Nubitdaverifier.contract.Call(opts, &out, "verifyMessage", hash, dataAvailabilityMessage)
+
+	if err != nil {
+		return err
+	}
+
+	return err
+
+}
+
+// VerifyMessage is a free data retrieval call binding the contract method 0x3b51be4b.
+//
+// Solidity: function verifyMessage(bytes32 hash, bytes dataAvailabilityMessage) view returns()
+func (_Nubitdaverifier *NubitdaverifierSession) VerifyMessage(hash [32]byte, dataAvailabilityMessage []byte) error {
+	return _Nubitdaverifier.Contract.VerifyMessage(&_Nubitdaverifier.CallOpts, hash, dataAvailabilityMessage)
+}
+
+// VerifyMessage is a free data retrieval call binding the contract method 0x3b51be4b.
+//
+// Solidity: function verifyMessage(bytes32 hash, bytes dataAvailabilityMessage) view returns()
+func (_Nubitdaverifier *NubitdaverifierCallerSession) VerifyMessage(hash [32]byte, dataAvailabilityMessage []byte) error {
+	return _Nubitdaverifier.Contract.VerifyMessage(&_Nubitdaverifier.CallOpts, hash, dataAvailabilityMessage)
+}
+
+// SetSequencer is a paid mutator transaction binding the contract method 0x2547fa3e.
+//
+// Solidity: function setSequencer(address newSequencer) returns()
+func (_Nubitdaverifier *NubitdaverifierTransactor) SetSequencer(opts *bind.TransactOpts, newSequencer common.Address) (*types.Transaction, error) {
+	return _Nubitdaverifier.contract.Transact(opts, "setSequencer", newSequencer)
+}
+
+// SetSequencer is a paid mutator transaction binding the contract method 0x2547fa3e.
+//
+// Solidity: function setSequencer(address newSequencer) returns()
+func (_Nubitdaverifier *NubitdaverifierSession) SetSequencer(newSequencer common.Address) (*types.Transaction, error) {
+	return _Nubitdaverifier.Contract.SetSequencer(&_Nubitdaverifier.TransactOpts, newSequencer)
+}
+
+// SetSequencer is a paid mutator transaction binding the contract method 0x2547fa3e.
+//
+// Solidity: function setSequencer(address newSequencer) returns()
+func (_Nubitdaverifier *NubitdaverifierTransactorSession) SetSequencer(newSequencer common.Address) (*types.Transaction, error) {
+	return _Nubitdaverifier.Contract.SetSequencer(&_Nubitdaverifier.TransactOpts, newSequencer)
+}
+
+// NubitdaverifierSetSequencerIterator is returned from FilterSetSequencer and is used to iterate over the raw logs and unpacked data for SetSequencer events raised by the Nubitdaverifier contract.
+type NubitdaverifierSetSequencerIterator struct {
+	Event *NubitdaverifierSetSequencer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NubitdaverifierSetSequencerIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NubitdaverifierSetSequencer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NubitdaverifierSetSequencer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NubitdaverifierSetSequencerIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NubitdaverifierSetSequencerIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NubitdaverifierSetSequencer represents a SetSequencer event raised by the Nubitdaverifier contract.
+type NubitdaverifierSetSequencer struct {
+	NewSequencer common.Address
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterSetSequencer is a free log retrieval operation binding the contract event 0xb6463e90f47a47e83f57f1427fd5d5c33461ba411b3a0911d11ecd3979c20039.
+//
+// Solidity: event SetSequencer(address newSequencer)
+func (_Nubitdaverifier *NubitdaverifierFilterer) FilterSetSequencer(opts *bind.FilterOpts) (*NubitdaverifierSetSequencerIterator, error) {
+
+	logs, sub, err := _Nubitdaverifier.contract.FilterLogs(opts, "SetSequencer")
+	if err != nil {
+		return nil, err
+	}
+	return &NubitdaverifierSetSequencerIterator{contract: _Nubitdaverifier.contract, event: "SetSequencer", logs: logs, sub: sub}, nil
+}
+
+// WatchSetSequencer is a free log subscription operation binding the contract event 0xb6463e90f47a47e83f57f1427fd5d5c33461ba411b3a0911d11ecd3979c20039.
+//
+// Solidity: event SetSequencer(address newSequencer)
+func (_Nubitdaverifier *NubitdaverifierFilterer) WatchSetSequencer(opts *bind.WatchOpts, sink chan<- *NubitdaverifierSetSequencer) (event.Subscription, error) {
+
+	logs, sub, err := _Nubitdaverifier.contract.WatchLogs(opts, "SetSequencer")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NubitdaverifierSetSequencer)
+				if err := _Nubitdaverifier.contract.UnpackLog(event, "SetSequencer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSetSequencer is a log parse operation binding the contract event 0xb6463e90f47a47e83f57f1427fd5d5c33461ba411b3a0911d11ecd3979c20039.
+//
+// Solidity: event SetSequencer(address newSequencer)
+func (_Nubitdaverifier *NubitdaverifierFilterer) ParseSetSequencer(log types.Log) (*NubitdaverifierSetSequencer, error) {
+	event := new(NubitdaverifierSetSequencer)
+	if err := _Nubitdaverifier.contract.UnpackLog(event, "SetSequencer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -8,5 +8,22 @@ gen() {
     abigen --bin bin/${package}.bin --abi abi/${package}.abi --pkg=${package} --out=${package}/${package}.go
 }
 
+# compile builds the bytecode and the ABI of a contract of the repo with solc 0.8.20, the imports
+# are resolved from the contracts directory of the zkevm contracts repo at CONTRACTS_PATH
+compile() {
+    local package=$1
+    local source=$2
+    local contract=$(basename ${source} .sol)
+    local out=$(mktemp -d)
+
+    solc --optimize --evm-version shanghai --base-path ../../contracts --include-path ${CONTRACTS_PATH} \
+        --abi --bin -o ${out} ../../contracts/${source}
+    cp ${out}/${contract}.bin bin/${package}.bin
+    cp ${out}/${contract}.abi abi/${package}.abi
+    rm -r ${out}
+}
+
 gen polygonvalidium_xlayer
 gen polygonrollupmanager
+compile nubitdaverifier validium/NubitDAVerifier.sol
+gen nubitdaverifier
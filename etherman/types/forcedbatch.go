@@ -0,0 +1,31 @@
+package types
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ForcedBatch represents a batch forced with the forceBatch method of the rollup
+type ForcedBatch struct {
+	ForcedBatchNumber uint64
+	GlobalExitRoot    common.Hash
+	// ForcedAt is the timestamp of the L1 block of the forceBatch tx
+	ForcedAt time.Time
+	// PrevBlockHash is the hash of the L1 block preceding the one of the forceBatch tx
+	PrevBlockHash common.Hash
+	Sequencer     common.Address
+	Transactions  []byte
+	BlockNumber   uint64
+	TxHash        common.Hash
+}
+
+// Hash returns the hash of the forced batch data stored by the rollup, the forced batch is only
+// sequenced with the data matching it
+func (b ForcedBatch) Hash() common.Hash {
+	var timestamp [8]byte
+	binary.BigEndian.PutUint64(timestamp[:], uint64(b.ForcedAt.Unix()))
+	return crypto.Keccak256Hash(crypto.Keccak256(b.Transactions), b.GlobalExitRoot.Bytes(), timestamp[:], b.PrevBlockHash.Bytes())
+}
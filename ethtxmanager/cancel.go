@@ -0,0 +1,213 @@
+package ethtxmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// replacementGasPriceBump is the minimum gas price increase in percent required by
+// the network to replace a pending tx with the same nonce
+const replacementGasPriceBump = 10
+
+var (
+	// ErrNotPending when the monitored tx can not be canceled or replaced because it is
+	// not pending anymore
+	ErrNotPending = errors.New("monitored tx is not pending")
+	// ErrBlobTxReplacement when trying to cancel or replace a blob tx, which can only be
+	// replaced by another blob tx
+	ErrBlobTxReplacement = errors.New("blob txs can not be canceled or replaced")
+)
+
+// Cancel abandons the monitored tx by sending a zero value self transfer with the same nonce
+// and bumped fees. The monitored tx is set as canceling until either the cancel tx gets mined,
+// setting it as canceled, or one of the txs sent before gets mined, setting it as confirmed.
+func (c *Client) Cancel(ctx context.Context, owner, id string) error {
+	unlock := c.locks.lock(owner, id)
+	defer unlock()
+
+	mTx, err := c.storage.Get(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+	if !mTx.isPending() {
+		return ErrNotPending
+	}
+	if mTx.isBlobTx() {
+		return ErrBlobTxReplacement
+	}
+
+	gasPrice, err := c.replacementGasPrice(ctx, mTx)
+	if err != nil {
+		return err
+	}
+
+	from := mTx.from
+	mTx.to = &from
+	mTx.value = big.NewInt(0)
+	mTx.data = nil
+	mTx.gas = params.TxGas
+	mTx.gasOffset = 0
+	mTx.gasPrice = gasPrice
+	mTx.status = MonitoredTxStatusCanceling
+	err = c.storage.Update(ctx, mTx)
+	if err != nil {
+		return fmt.Errorf("failed to update canceling monitored tx: %w", err)
+	}
+	c.publishResult(ctx, mTx)
+	fmt.Printf("canceling monitored tx: %v\n", mTx.id)
+
+	_, err = c.sendMonitoredTx(ctx, &mTx)
+	if err != nil {
+		// the cancel tx is sent again on the next monitoring cycle
+		fmt.Printf("failed to send cancel tx of monitored tx %v: %v\n", mTx.id, err)
+	}
+	return nil
+}
+
+// Replace sends the monitored tx again with the new data, keeping the same nonce and bumping
+// the fees, so the new data replaces the data of the txs sent before if they were not mined yet.
+// All the sent txs are kept in the monitored tx history.
+func (c *Client) Replace(ctx context.Context, owner, id string, newData []byte) error {
+	unlock := c.locks.lock(owner, id)
+	defer unlock()
+
+	mTx, err := c.storage.Get(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+	if mTx.status != MonitoredTxStatusCreated && mTx.status != MonitoredTxStatusSent {
+		return ErrNotPending
+	}
+	if mTx.isBlobTx() {
+		return ErrBlobTxReplacement
+	}
+
+	// get gas
+	gas, err := c.etherman.EstimateGas(ctx, mTx.from, mTx.to, mTx.value, newData)
+	if err != nil {
+		err := fmt.Errorf("failed to estimate gas: %w, data: %v", err, common.Bytes2Hex(newData))
+		if c.cfg.ForcedGas > 0 {
+			gas = c.cfg.ForcedGas
+		} else {
+			return err
+		}
+	}
+
+	gasPrice, err := c.replacementGasPrice(ctx, mTx)
+	if err != nil {
+		return err
+	}
+
+	mTx.data = newData
+	mTx.gas = gas
+	mTx.gasPrice = gasPrice
+
+	// the replacement tx is added to the history along with the new data, so it is monitored
+	// even if sending it fails
+	replacement, err := c.etherman.SignTx(ctx, mTx.from, mTx.Tx())
+	if err != nil {
+		return fmt.Errorf("failed to sign replacement tx: %w", err)
+	}
+	err = mTx.AddHistory(replacement)
+	if err != nil && !errors.Is(err, ErrAlreadyExists) {
+		return fmt.Errorf("failed to add replacement tx to monitored tx history: %w", err)
+	}
+	err = c.storage.Update(ctx, mTx)
+	if err != nil {
+		return fmt.Errorf("failed to update replaced monitored tx: %w", err)
+	}
+	c.publishResult(ctx, mTx)
+	fmt.Printf("replacing monitored tx: %v with tx %v\n", mTx.id, replacement.Hash().String())
+
+	_, err = c.sendMonitoredTx(ctx, &mTx)
+	if err != nil {
+		// the replacement tx is sent again on the next monitoring cycle
+		fmt.Printf("failed to send replacement tx of monitored tx %v: %v\n", mTx.id, err)
+	}
+	return nil
+}
+
+// replacementGasPrice returns the highest of the suggested gas price and the gas price of the
+// monitored tx bumped enough to replace the pending tx in the network
+func (c *Client) replacementGasPrice(ctx context.Context, mTx monitoredTx) (*big.Int, error) {
+	gasPrice, err := c.suggestedGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suggested gas price: %w", err)
+	}
+
+	// round up the bumped gas price to not fall below the required increase
+	hundred := big.NewInt(100) //nolint:gomnd
+	bumped := new(big.Int).Mul(mTx.gasPrice, big.NewInt(100+replacementGasPriceBump))
+	bumped.Add(bumped, new(big.Int).Sub(hundred, big.NewInt(1)))
+	bumped.Div(bumped, hundred)
+	if bumped.Cmp(gasPrice) == 1 {
+		gasPrice = bumped
+	}
+	return gasPrice, nil
+}
+
+// reloadPendingMonitoredTx loads the monitored tx again from the storage, and returns if it
+// is still pending to be monitored
+func (c *Client) reloadPendingMonitoredTx(ctx context.Context, mTx monitoredTx) (monitoredTx, bool) {
+	reloaded, err := c.storage.Get(ctx, mTx.owner, mTx.id)
+	if err != nil {
+		fmt.Printf("failed to reload monitored tx %v: %v\n", mTx.id, err)
+		return mTx, false
+	}
+	return reloaded, reloaded.isPending()
+}
+
+// isCancelTx checks if the tx is a zero value self transfer of the sender
+func isCancelTx(from common.Address, tx *types.Transaction) bool {
+	return tx.To() != nil && *tx.To() == from && len(tx.Data()) == 0 && tx.Value().Sign() == 0
+}
+
+// monitoredTxLocks serializes the changes to the same monitored tx made by the monitoring
+// cycles and by the owner canceling or replacing it. The lock of a monitored tx is removed once
+// nobody holds it or waits for it.
+type monitoredTxLocks struct {
+	mutex sync.Mutex
+	locks map[monitoredTxKey]*monitoredTxLock
+}
+
+// monitoredTxLock is the lock of a monitored tx, with the number of callers holding it or
+// waiting for it
+type monitoredTxLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the monitored tx and returns the function to unlock it
+func (l *monitoredTxLocks) lock(owner, id string) func() {
+	l.mutex.Lock()
+	if l.locks == nil {
+		l.locks = map[monitoredTxKey]*monitoredTxLock{}
+	}
+	key := monitoredTxKey{owner: owner, id: id}
+	lock, found := l.locks[key]
+	if !found {
+		lock = &monitoredTxLock{}
+		l.locks[key] = lock
+	}
+	lock.refs++
+	l.mutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		l.mutex.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mutex.Unlock()
+	}
+}
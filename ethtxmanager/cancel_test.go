@@ -0,0 +1,164 @@
+package ethtxmanager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendTestingTx(t *testing.T, c *Client, etherman *fakeEtherman, id string, data []byte) {
+	t.Helper()
+	ctx := context.Background()
+	to := common.HexToAddress("0x2")
+	require.NoError(t, c.Add(ctx, testOwner, id, etherman.address(), &to, nil, data, 0))
+	require.NoError(t, c.monitorTxs(ctx))
+	result, err := c.Result(ctx, testOwner, id)
+	require.NoError(t, err)
+	require.Equal(t, MonitoredTxStatusSent, result.Status)
+}
+
+func TestCancel(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	sendTestingTx(t, c, etherman, "id", []byte{1, 2, 3})
+	original := etherman.lastSent()
+
+	require.NoError(t, c.Cancel(ctx, testOwner, "id"))
+
+	// A zero value self transfer is sent right away with the same nonce and bumped fees
+	cancelTx := etherman.lastSent()
+	require.NotEqual(t, original.Hash(), cancelTx.Hash())
+	assert.Equal(t, original.Nonce(), cancelTx.Nonce())
+	assert.Equal(t, etherman.address(), *cancelTx.To())
+	assert.Equal(t, 0, cancelTx.Value().Sign())
+	assert.Empty(t, cancelTx.Data())
+	assert.Equal(t, big.NewInt(2), cancelTx.GasPrice())
+
+	result, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusCanceling, result.Status)
+	assert.Len(t, result.Txs, 2)
+
+	// The cancel tx gets mined
+	etherman.mine(cancelTx)
+	require.NoError(t, c.monitorTxs(ctx))
+	result, err = c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusCanceled, result.Status)
+
+	// The canceled monitored tx is reported once to the pending monitored txs handler
+	var results []MonitoredTxResult
+	c.ProcessPendingMonitoredTxs(ctx, testOwner, func(result MonitoredTxResult) {
+		results = append(results, result)
+	})
+	require.Len(t, results, 1)
+	assert.Equal(t, MonitoredTxStatusCanceled, results[0].Status)
+	result, err = c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusDone, result.Status)
+}
+
+func TestCancelOriginalTxMinedFirst(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	sendTestingTx(t, c, etherman, "id", []byte{1, 2, 3})
+	original := etherman.lastSent()
+
+	require.NoError(t, c.Cancel(ctx, testOwner, "id"))
+
+	// The original tx wins the race against the cancel tx
+	etherman.mine(original)
+	require.NoError(t, c.monitorTxs(ctx))
+	result, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusConfirmed, result.Status)
+
+	err = c.Cancel(ctx, testOwner, "id")
+	require.ErrorIs(t, err, ErrNotPending)
+}
+
+func TestCancelWhileWaitingTxToBeMined(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	to := common.HexToAddress("0x2")
+	require.NoError(t, c.Add(ctx, testOwner, "id", etherman.address(), &to, nil, []byte{1, 2, 3}, 0))
+
+	etherman.waiting = make(chan struct{})
+	etherman.release = make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- c.monitorTxs(ctx)
+	}()
+	<-etherman.waiting
+	original := etherman.lastSent()
+
+	// The monitored tx is canceled while its tx is waited to be mined
+	etherman.waiting = nil
+	require.NoError(t, c.Cancel(ctx, testOwner, "id"))
+	result, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusCanceling, result.Status)
+
+	// The original tx gets mined, and the monitored tx is loaded again with the cancel tx
+	etherman.mine(original)
+	close(etherman.release)
+	require.NoError(t, <-done)
+	result, err = c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusConfirmed, result.Status)
+	assert.Len(t, result.Txs, 2)
+
+	// The locks are removed once released
+	assert.Empty(t, c.locks.locks)
+}
+
+func TestReplace(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	sendTestingTx(t, c, etherman, "id", []byte{1, 2, 3})
+	original := etherman.lastSent()
+	sub := c.Subscribe(testOwner)
+	defer sub.Unsubscribe()
+
+	newData := []byte{4, 5, 6}
+	require.NoError(t, c.Replace(ctx, testOwner, "id", newData))
+
+	// The new data is sent right away with the same nonce and bumped fees
+	replacement := etherman.lastSent()
+
+	// The replacement is published with both txs in the history
+	published := receiveResult(t, sub)
+	assert.Equal(t, MonitoredTxStatusSent, published.Status)
+	assert.Contains(t, published.Txs, original.Hash())
+	assert.Contains(t, published.Txs, replacement.Hash())
+	require.NotEqual(t, original.Hash(), replacement.Hash())
+	assert.Equal(t, original.Nonce(), replacement.Nonce())
+	assert.Equal(t, *original.To(), *replacement.To())
+	assert.Equal(t, newData, replacement.Data())
+	assert.Equal(t, big.NewInt(2), replacement.GasPrice())
+
+	// Both txs are kept in the history, and the replacement gets mined
+	etherman.mine(replacement)
+	require.NoError(t, c.monitorTxs(ctx))
+	result, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusConfirmed, result.Status)
+	require.Len(t, result.Txs, 2)
+	assert.NotNil(t, result.Txs[replacement.Hash()].Receipt)
+	assert.Nil(t, result.Txs[original.Hash()].Receipt)
+
+	err = c.Replace(ctx, testOwner, "id", newData)
+	require.ErrorIs(t, err, ErrNotPending)
+}
+
+func TestCancelAndReplaceNotFound(t *testing.T) {
+	c, _ := newTestingClient(t, Config{})
+	ctx := context.Background()
+
+	require.ErrorIs(t, c.Cancel(ctx, testOwner, "id"), ErrNotFound)
+	require.ErrorIs(t, c.Replace(ctx, testOwner, "id", nil), ErrNotFound)
+}
@@ -1,9 +1,23 @@
 package ethtxmanager
 
 import (
+	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/sieniven/zkevm-nubit/config/types"
 )
 
+// StorageType is the backend used to store the monitored txs
+type StorageType string
+
+const (
+	// StorageTypeMemory keeps the monitored txs in memory, they are lost on restart
+	StorageTypeMemory StorageType = "memory"
+	// StorageTypeLevelDB persists the monitored txs in an embedded LevelDB database
+	StorageTypeLevelDB StorageType = "leveldb"
+	// StorageTypePostgres persists the monitored txs in the zkevm-node state.monitored_txs
+	// postgres table
+	StorageTypePostgres StorageType = "postgres"
+)
+
 type Config struct {
 	// FrequencyToMonitorTxs frequency of the resending failed txs
 	FrequenceToMonitorTxs types.Duration `mapstructure:"FrequencyToMonitorTxs"`
@@ -45,4 +59,26 @@ type Config struct {
 	// max gas price limit: 110
 	// tx gas price = 110
 	MaxGasPriceLimit uint64 `mapstructure:"MaxGasPriceLimit"`
+
+	// MaxHistorySize is the maximum amount of txs that can be signed and sent to the network
+	// for a single monitored tx, default value is 0, which means no limit. Each resubmission
+	// with reviewed gas or gas price adds a tx to the monitored tx history. When the limit
+	// is reached, the monitored tx is marked as failed and it is not resubmitted anymore.
+	MaxHistorySize uint64 `mapstructure:"MaxHistorySize"`
+
+	// MaxMonitoredTxAge is the maximum time a monitored tx can be monitored without getting
+	// confirmed, default value is 0, which means no limit. When the limit is reached, the
+	// monitored tx is marked as failed and it is not resubmitted anymore.
+	MaxMonitoredTxAge types.Duration `mapstructure:"MaxMonitoredTxAge"`
+
+	// StorageType defines where the monitored txs are stored, "memory", "leveldb" or "postgres".
+	// Only the durable storages allow the monitored txs to be resumed after a restart.
+	StorageType StorageType `mapstructure:"StorageType"`
+
+	// StoragePath is the directory of the embedded database used by the durable storages
+	StoragePath string `mapstructure:"StoragePath"`
+
+	// DB is the postgres database configuration used by the postgres storage. It can
+	// point to the state database of a zkevm-node to share its monitored txs table.
+	DB db.Config `mapstructure:"DB"`
 }
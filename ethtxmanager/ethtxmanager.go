@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"sync"
 	"time"
@@ -35,92 +36,64 @@ var (
 	ErrExecutionReverted = errors.New("execution reverted")
 )
 
-type MonitoredTxsStorage struct {
-	inner map[string]monitoredTx
-	mutex *sync.RWMutex
-}
-
-func (s *MonitoredTxsStorage) Get(ctx context.Context, owner *string, id string) (monitoredTx, error) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	mTx, ok := s.inner[id]
-	if ok {
-		return mTx, nil
-	} else {
-		if owner != nil {
-			for _, mTx := range s.inner {
-				if mTx.owner == *owner {
-					return mTx, nil
-				}
-			}
-		}
-		return monitoredTx{}, ErrNotFound
-	}
-}
-
-func (s *MonitoredTxsStorage) GetByStatus(ctx context.Context, owner *string, statusesFilter []MonitoredTxStatus) ([]monitoredTx, error) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	mTxs := []monitoredTx{}
-	for _, mTx := range s.inner {
-		if owner == nil || *owner == mTx.owner {
-			for _, status := range statusesFilter {
-				if mTx.status == status {
-					mTxs = append(mTxs, mTx)
-				}
-			}
-		}
-	}
-	return mTxs, nil
-}
-
-func (s *MonitoredTxsStorage) Add(ctx context.Context, mTx monitoredTx) error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.inner[mTx.id] = mTx
-	return nil
-}
-
-func (s *MonitoredTxsStorage) Update(ctx context.Context, mTx monitoredTx) error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.inner[mTx.id] = mTx
-	return nil
-}
-
 type Client struct {
+	// ctx is canceled by Stop, stopping Start, which holds running until it returns
 	ctx      context.Context
 	cancel   context.CancelFunc
+	running  sync.Mutex
 	cfg      Config
-	etherman *etherman.Client
-	storage  MonitoredTxsStorage
+	etherman ethermanInterface
+	storage  storageInterface
+	nonces   *nonceManager
+	locks    monitoredTxLocks
+
+	subscriptions subscriptions
 }
 
 // Factory method for a new eth tx manager instance
-func New(cfg Config, etherMan *etherman.Client) *Client {
-	// Initialize monitored txs in-memory storage
-	s := MonitoredTxsStorage{
-		inner: map[string]monitoredTx{},
-		mutex: &sync.RWMutex{},
+func New(cfg Config, etherMan *etherman.Client) (*Client, error) {
+	// Initialize monitored txs storage
+	s, err := newStorage(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create monitored txs storage: %w", err)
 	}
 
-	c := &Client{
+	return newClient(cfg, etherMan, s), nil
+}
+
+// newClient creates the eth tx manager with the given etherman and monitored txs storage
+func newClient(cfg Config, etherMan ethermanInterface, storage storageInterface) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Client{
+		ctx:      ctx,
+		cancel:   cancel,
 		cfg:      cfg,
 		etherman: etherMan,
-		storage:  s,
+		storage:  storage,
+		nonces:   newNonceManager(etherMan, storage),
+	}
+}
+
+// newStorage creates the monitored txs storage according to the configured storage type
+func newStorage(cfg Config) (storageInterface, error) {
+	switch cfg.StorageType {
+	case StorageTypeMemory, "":
+		return NewMonitoredTxsStorage(), nil
+	case StorageTypeLevelDB:
+		return NewLevelDBStorage(cfg.StoragePath)
+	case StorageTypePostgres:
+		err := RunMigrationsUp(cfg.DB)
+		if err != nil {
+			return nil, err
+		}
+		return NewPostgresStorage(cfg.DB)
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", cfg.StorageType)
 	}
-	return c
 }
 
 // Add a transaction to be sent and monitored
 func (c *Client) Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64) error {
-	// get next nonce
-	nonce, err := c.etherman.CurrentNonce(ctx, from)
-	if err != nil {
-		return fmt.Errorf("failed to get current nonce: %w", err)
-	}
 	// get gas
 	gas, err := c.etherman.EstimateGas(ctx, from, to, value, data)
 	if err != nil {
@@ -141,21 +114,22 @@ func (c *Client) Add(ctx context.Context, owner, id string, from common.Address,
 	// create monitored tx
 	mTx := monitoredTx{
 		owner: owner, id: id, from: from, to: to,
-		nonce: nonce, value: value, data: data,
+		value: value, data: data,
 		gas: gas, gasOffset: gasOffset, gasPrice: gasPrice,
 		status: MonitoredTxStatusCreated,
 		// initialize empty map
 		history: map[common.Hash]bool{},
 		// blockNumber is unused here
 		blockNumber: big.NewInt(0),
-		// createdAt is unused here
-		createdAt: time.Now(),
-		// updatedAt is unused here
-		updatedAt: time.Now(),
+		createdAt:   time.Now(),
+		updatedAt:   time.Now(),
 	}
 
-	// add to storage
-	err = c.storage.Add(ctx, mTx)
+	// assign the next nonce of the sender and add to storage
+	err = c.nonces.reserve(ctx, from, func(nonce uint64) error {
+		mTx.nonce = nonce
+		return c.storage.Add(ctx, mTx)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to add tx to get monitored: %w", err)
 	}
@@ -163,6 +137,69 @@ func (c *Client) Add(ctx context.Context, owner, id string, from common.Address,
 	return nil
 }
 
+// AddBlob adds an EIP-4844 blob transaction carrying the provided sidecar to be sent and monitored
+func (c *Client) AddBlob(ctx context.Context, owner, id string, from common.Address, to *common.Address, sidecar *types.BlobTxSidecar, gasOffset uint64) error {
+	if to == nil {
+		return errors.New("blob tx requires a receiver")
+	}
+	if sidecar == nil || len(sidecar.Blobs) == 0 {
+		return errors.New("blob tx requires at least one blob")
+	}
+
+	// get gas
+	gas, err := c.etherman.EstimateGas(ctx, from, to, nil, nil)
+	if err != nil {
+		err := fmt.Errorf("failed to estimate gas: %w", err)
+		if c.cfg.ForcedGas > 0 {
+			gas = c.cfg.ForcedGas
+		} else {
+			return err
+		}
+	}
+
+	// get gas price
+	gasPrice, err := c.suggestedGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get suggested gas price: %w", err)
+	}
+
+	// get gas tip cap
+	gasTipCap, err := c.suggestedGasTipCap(ctx, gasPrice)
+	if err != nil {
+		return fmt.Errorf("failed to get suggested gas tip cap: %w", err)
+	}
+
+	// get blob gas price
+	blobGasPrice, err := c.suggestedBlobGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get suggested blob gas price: %w", err)
+	}
+
+	// create monitored tx
+	mTx := monitoredTx{
+		owner: owner, id: id, from: from, to: to, value: big.NewInt(0),
+		gas: gas, gasOffset: gasOffset, gasPrice: gasPrice, gasTipCap: gasTipCap,
+		blobSidecar: sidecar, blobGasPrice: blobGasPrice,
+		status: MonitoredTxStatusCreated,
+		// initialize empty map
+		history:     map[common.Hash]bool{},
+		blockNumber: big.NewInt(0),
+		createdAt:   time.Now(),
+		updatedAt:   time.Now(),
+	}
+
+	// assign the next nonce of the sender and add to storage
+	err = c.nonces.reserve(ctx, from, func(nonce uint64) error {
+		mTx.nonce = nonce
+		return c.storage.Add(ctx, mTx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add blob tx to get monitored: %w", err)
+	}
+	fmt.Printf("created monitored blob tx: %v\n", mTx.id)
+	return nil
+}
+
 // ResultsByStatus returns all the results for all the monitored txs related to the owner and matching the provided statuses
 // if the statuses are empty, all the statuses are considered.
 //
@@ -188,7 +225,7 @@ func (c *Client) ResultsByStatus(ctx context.Context, owner string, statuses []M
 
 // Result returns the current result of the transaction execution with all the details
 func (c *Client) Result(ctx context.Context, owner, id string) (MonitoredTxResult, error) {
-	mTx, err := c.storage.Get(ctx, &owner, id)
+	mTx, err := c.storage.Get(ctx, owner, id)
 	if err != nil {
 		return MonitoredTxResult{}, err
 	}
@@ -200,14 +237,19 @@ func (c *Client) Result(ctx context.Context, owner, id string) (MonitoredTxResul
 // this method is provided to the callers to decide when a monitored tx should be
 // considered done, so they can start to ignore it when querying it by Status.
 func (c *Client) setStatusDone(ctx context.Context, owner, id string) error {
-	mTx, err := c.storage.Get(ctx, &owner, id)
+	mTx, err := c.storage.Get(ctx, owner, id)
 	if err != nil {
 		return err
 	}
 
 	mTx.status = MonitoredTxStatusDone
 
-	return c.storage.Update(ctx, mTx)
+	err = c.storage.Update(ctx, mTx)
+	if err != nil {
+		return err
+	}
+	c.publishResult(ctx, mTx)
+	return nil
 }
 
 func (c *Client) buildResult(ctx context.Context, mTx monitoredTx) (MonitoredTxResult, error) {
@@ -238,9 +280,10 @@ func (c *Client) buildResult(ctx context.Context, mTx monitoredTx) (MonitoredTxR
 	}
 
 	result := MonitoredTxResult{
-		ID:     mTx.id,
-		Status: mTx.status,
-		Txs:    txs,
+		ID:            mTx.id,
+		Status:        mTx.status,
+		Txs:           txs,
+		FailureReason: mTx.failureReason,
 	}
 
 	return result, nil
@@ -248,17 +291,34 @@ func (c *Client) buildResult(ctx context.Context, mTx monitoredTx) (MonitoredTxR
 
 // Start will start the tx management, reading txs from the storage and
 // send them to the L1 blockchain. It will keep monitoring them until
-// they get minted.
-func (c *Client) Start() {
-	// Infinite loop to manage txs as they arrive
-	c.ctx, c.cancel = context.WithCancel(context.Background())
+// they get minted, or until the context is done or Stop is called. The
+// monitoring cycle in progress stops waiting for the txs to be mined, but
+// the txs being sent are stored before returning.
+func (c *Client) Start(ctx context.Context) {
+	c.running.Lock()
+	defer c.running.Unlock()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stopCancel := context.AfterFunc(c.ctx, cancel)
+	defer stopCancel()
+
+	// Resume monitoring the txs left pending by a previous run
+	err := c.resumePendingTxs(ctx)
+	if err != nil {
+		c.logErrorAndWait("failed to resume pending monitored txs: %v", err)
+	}
 
+	// Infinite loop to manage txs as they arrive
 	for {
 		select {
-		case <-c.ctx.Done():
+		case <-ctx.Done():
 			return
 		case <-time.After(c.cfg.FrequenceToMonitorTxs.Duration):
-			err := c.monitorTxs(context.Background())
+			// both cases may be ready, a new monitoring cycle is not started once stopping
+			if ctx.Err() != nil {
+				return
+			}
+			err := c.monitorTxs(ctx)
 			if err != nil {
 				c.logErrorAndWait("failed to monitor txs: %v", err)
 			}
@@ -266,9 +326,39 @@ func (c *Client) Start() {
 	}
 }
 
-// Stop will stops the monitored tx management
+// ForcedGas returns the gas of the txs whose gas estimation fails, zero if they are not sent
+func (c *Client) ForcedGas() uint64 {
+	return c.cfg.ForcedGas
+}
+
+// Stop will stops the monitored tx management, and closes the monitored txs storage once
+// Start returned
 func (c *Client) Stop() {
 	c.cancel()
+	c.running.Lock()
+	defer c.running.Unlock()
+	if closer, ok := c.storage.(io.Closer); ok {
+		err := closer.Close()
+		if err != nil {
+			fmt.Printf("failed to close monitored txs storage: %v\n", err)
+		}
+	}
+}
+
+// resumePendingTxs loads the monitored txs that were still pending when the node stopped,
+// and processes them right away instead of waiting for the next monitoring cycle
+func (c *Client) resumePendingTxs(ctx context.Context) error {
+	statusesFilter := []MonitoredTxStatus{MonitoredTxStatusCreated, MonitoredTxStatusSent, MonitoredTxStatusCanceling}
+	mTxs, err := c.storage.GetByStatus(context.WithoutCancel(ctx), nil, statusesFilter)
+	if err != nil {
+		return err
+	}
+	if len(mTxs) == 0 {
+		return nil
+	}
+
+	fmt.Printf("resuming %v pending monitored txs\n", len(mTxs))
+	return c.monitorTxs(ctx)
 }
 
 // logErrorAndWait used when an error is detected before trying again
@@ -277,10 +367,11 @@ func (c *Client) logErrorAndWait(msg string, err error) {
 	time.Sleep(failureIntervalInSeconds * time.Second)
 }
 
-// monitorTxs process all pending monitored transactions
+// monitorTxs process all pending monitored transactions. Once the context is done, the
+// monitored txs stop waiting for their txs to be mined.
 func (c *Client) monitorTxs(ctx context.Context) error {
-	statusesFilter := []MonitoredTxStatus{MonitoredTxStatusCreated, MonitoredTxStatusSent}
-	mTxs, err := c.storage.GetByStatus(ctx, nil, statusesFilter)
+	statusesFilter := []MonitoredTxStatus{MonitoredTxStatusCreated, MonitoredTxStatusSent, MonitoredTxStatusCanceling}
+	mTxs, err := c.storage.GetByStatus(context.WithoutCancel(ctx), nil, statusesFilter)
 	if err != nil {
 		return fmt.Errorf("failed to get created monitored txs: %v", err)
 	}
@@ -306,6 +397,20 @@ func (c *Client) monitorTxs(ctx context.Context) error {
 
 // monitorTx does all the monitoring steps to the monitored tx
 func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx) {
+	// the changes to the monitored tx are stored even if the context is done, only waiting
+	// for its tx to be mined is interrupted, so it is monitored again when resumed
+	waitCtx := ctx
+	ctx = context.WithoutCancel(ctx)
+
+	// the monitored tx may have been canceled or replaced since it was loaded, so it is
+	// loaded again while holding its lock
+	unlock := c.locks.lock(mTx.owner, mTx.id)
+	defer func() { unlock() }()
+	mTx, pending := c.reloadPendingMonitoredTx(ctx, mTx)
+	if !pending {
+		return
+	}
+
 	// check if any of the txs in the history was confirmed
 	var lastReceiptChecked types.Receipt
 	// monitored tx is confirmed until we find a successful receipt
@@ -366,26 +471,35 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx) {
 		}
 	}
 
-	// If the history size reaches the max history size, this means that something is really wrong
-	// with this tx and we are not able to identify automatically, so we can mark this as failed to
-	// let the caller know something is not right and needs to be reviewed. We also do not want to
-	// be reviewing and monitoring this tx indefinitely.
-	// if len(mTx.history) == maxHistorySize {
-	// 	mTx.status = MonitoredTxStatusFailed
-	// 	fmt.Printf("marked as failed because reached the history size limit: %v", err)
-	// 	// update monitored tx changes into storage
-	// 	err = c.storage.Update(ctx, mTx)
-	// 	if err != nil {
-	// 		fmt.Printf("failed to update monitored tx when max history size limit reached: %v", err)
-	// 		continue
-	// 	}
-	// }
+	// If the history size reaches the max history size, or the monitored tx gets too old, this
+	// means that something is really wrong with this tx and we are not able to identify
+	// automatically, so we can mark this as failed to let the caller know something is not
+	// right and needs to be reviewed. We also do not want to be reviewing and monitoring this
+	// tx indefinitely.
+	if !confirmed {
+		if reason, failed := c.exceedsMonitoringLimits(mTx); failed {
+			mTx.status = MonitoredTxStatusFailed
+			mTx.failureReason = reason
+			fmt.Printf("monitored tx %v marked as failed: %v\n", mTx.id, reason)
+			// the nonce of the failed monitored tx may not be consumed, so the sender
+			// nonces are synced again
+			c.nonces.resync(mTx.from)
+			// update monitored tx changes into storage
+			err := c.storage.Update(ctx, mTx)
+			if err != nil {
+				fmt.Printf("failed to update monitored tx when monitoring limit reached: %v\n", err)
+				return
+			}
+			c.publishResult(ctx, mTx)
+			return
+		}
+	}
 
 	var signedTx *types.Transaction
 	var err error
 	if !confirmed {
 		// review tx and increase gas and gas price if needed
-		if mTx.status == MonitoredTxStatusSent {
+		if mTx.status == MonitoredTxStatusSent || mTx.status == MonitoredTxStatusCanceling {
 			err := c.reviewMonitoredTx(ctx, &mTx)
 			if err != nil {
 				fmt.Printf("failed to review monitored tx: %v\n", err)
@@ -398,64 +512,20 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx) {
 			}
 		}
 
-		// rebuild transaction
-		tx := mTx.Tx()
-		fmt.Printf("unsigned tx %v created\n", tx.Hash().String())
-
-		// sign tx
-		signedTx, err = c.etherman.SignTx(ctx, mTx.from, tx)
+		// sign and send the tx to the network
+		signedTx, err = c.sendMonitoredTx(ctx, &mTx)
 		if err != nil {
-			fmt.Printf("failed to sign tx %v: %v", tx.Hash().String(), err)
+			fmt.Printf("failed to send monitored tx: %v\n", err)
 			return
 		}
 
-		// add tx to monitored tx history
-		err = mTx.AddHistory(signedTx)
-		if errors.Is(err, ErrAlreadyExists) {
-			fmt.Println("signed tx already existed in the history")
-		} else if err != nil {
-			fmt.Printf("failed to add signed tx %v to monitored tx history: %v\n", signedTx.Hash().String(), err)
-			return
-		} else {
-			// Update monitored tx changes into storage
-			err = c.storage.Update(ctx, mTx)
-			if err != nil {
-				fmt.Printf("failed to update monitored tx: %v\n", err)
-				return
-			}
-			fmt.Println("signed tx added to the monitored tx history")
-		}
-
-		// Check if the tx is already in the network. If not, send it
-		_, _, err = c.etherman.GetTx(ctx, signedTx.Hash())
-		// if not found, send it tx to the network
-		if errors.Is(err, ethereum.NotFound) {
-			fmt.Println("signed tx not found in the network")
-			err := c.etherman.SendTx(ctx, signedTx)
-			if err != nil {
-				fmt.Printf("failed to send tx %v to network: %v\n", signedTx.Hash().String(), err)
-				return
-			}
-			fmt.Printf("signed tx sent to the network: %v\n", signedTx.Hash().String())
-			if mTx.status == MonitoredTxStatusCreated {
-				// update tx status to sent
-				mTx.status = MonitoredTxStatusSent
-				fmt.Printf("status changed to %v\n", string(mTx.status))
-				// update monitored tx changes into storage
-				err = c.storage.Update(ctx, mTx)
-				if err != nil {
-					fmt.Printf("failed to update monitored tx changes: %v\n", err)
-					return
-				}
-			}
-		} else {
-			fmt.Println("signed tx already found in the network")
-		}
-
 		fmt.Println("waiting signedTx to be mined...")
 
-		// wait tx to get mined
-		confirmed, err = c.etherman.WaitTxToBeMined(ctx, signedTx, c.cfg.WaitTxToBeMined.Duration)
+		// wait tx to get mined, without holding the lock so the monitored tx can be canceled
+		// or replaced meanwhile, in which case it is loaded again once the tx is mined
+		unlock()
+		confirmed, err = c.etherman.WaitTxToBeMined(waitCtx, signedTx, c.cfg.WaitTxToBeMined.Duration)
+		unlock = c.locks.lock(mTx.owner, mTx.id)
 		if err != nil {
 			fmt.Printf("failed to wait tx to be mined: %v\n", err)
 			return
@@ -474,30 +544,138 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx) {
 		}
 		lastReceiptChecked = *txReceipt
 
-		// if mined, check receipt and mark as Failed or Confirmed
-		if lastReceiptChecked.Status == types.ReceiptStatusSuccessful {
-			mTx.status = MonitoredTxStatusConfirmed
-			mTx.blockNumber = lastReceiptChecked.BlockNumber
-			fmt.Printf("Tx hash %v confirmed\n", signedTx.Hash())
-		} else {
-			// if we should continue to monitor, we move to the next one and this will
-			// be reviewed in the next monitoring cycle
-			if c.shouldContinueToMonitorThisTx(ctx, lastReceiptChecked) {
-				return
-			}
-			// otherwise we understand this monitored tx has failed
-			mTx.status = MonitoredTxStatusFailed
-			mTx.blockNumber = lastReceiptChecked.BlockNumber
-			fmt.Printf("Tx hash %v failed\n", signedTx.Hash())
+		mTx, pending = c.reloadPendingMonitoredTx(ctx, mTx)
+		if !pending {
+			return
 		}
+	}
 
-		// update monitored tx changes into storage
-		err = c.storage.Update(ctx, mTx)
-		if err != nil {
-			fmt.Printf("failed to update monitored tx: %v\n", err)
+	// if mined, check receipt and mark as Failed, Canceled or Confirmed
+	if lastReceiptChecked.Status == types.ReceiptStatusSuccessful {
+		mTx.status = c.minedStatus(ctx, mTx, lastReceiptChecked.TxHash)
+		mTx.blockNumber = lastReceiptChecked.BlockNumber
+		fmt.Printf("Tx hash %v %v\n", lastReceiptChecked.TxHash, mTx.status)
+	} else {
+		// if we should continue to monitor, we move to the next one and this will
+		// be reviewed in the next monitoring cycle
+		if c.shouldContinueToMonitorThisTx(ctx, lastReceiptChecked) {
 			return
 		}
+		// otherwise we understand this monitored tx has failed
+		mTx.status = MonitoredTxStatusFailed
+		mTx.failureReason = c.revertReason(ctx, lastReceiptChecked.TxHash)
+		mTx.blockNumber = lastReceiptChecked.BlockNumber
+		fmt.Printf("Tx hash %v failed\n", lastReceiptChecked.TxHash)
+		c.nonces.resync(mTx.from)
+	}
+
+	// update monitored tx changes into storage
+	err = c.storage.Update(ctx, mTx)
+	if err != nil {
+		fmt.Printf("failed to update monitored tx: %v\n", err)
+		return
+	}
+	c.publishResult(ctx, mTx)
+}
+
+// sendMonitoredTx builds and signs the tx of the monitored tx, adds it to the monitored tx
+// history and sends it to the network if it is not there yet
+func (c *Client) sendMonitoredTx(ctx context.Context, mTx *monitoredTx) (*types.Transaction, error) {
+	// rebuild transaction
+	tx := mTx.Tx()
+	fmt.Printf("unsigned tx %v created\n", tx.Hash().String())
+
+	// sign tx
+	signedTx, err := c.etherman.SignTx(ctx, mTx.from, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx %v: %w", tx.Hash().String(), err)
+	}
+
+	// add tx to monitored tx history
+	err = mTx.AddHistory(signedTx)
+	if errors.Is(err, ErrAlreadyExists) {
+		fmt.Println("signed tx already existed in the history")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to add signed tx %v to monitored tx history: %w", signedTx.Hash().String(), err)
+	} else {
+		// Update monitored tx changes into storage
+		err = c.storage.Update(ctx, *mTx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update monitored tx: %w", err)
+		}
+		fmt.Println("signed tx added to the monitored tx history")
+	}
+
+	// Check if the tx is already in the network. If not, send it
+	_, _, err = c.etherman.GetTx(ctx, signedTx.Hash())
+	// if not found, send it tx to the network
+	if errors.Is(err, ethereum.NotFound) {
+		fmt.Println("signed tx not found in the network")
+		err := c.etherman.SendTx(ctx, signedTx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send tx %v to network: %w", signedTx.Hash().String(), err)
+		}
+		fmt.Printf("signed tx sent to the network: %v\n", signedTx.Hash().String())
+		if mTx.status == MonitoredTxStatusCreated {
+			// update tx status to sent
+			mTx.status = MonitoredTxStatusSent
+			fmt.Printf("status changed to %v\n", string(mTx.status))
+			// update monitored tx changes into storage
+			err = c.storage.Update(ctx, *mTx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update monitored tx changes: %w", err)
+			}
+			c.publishResult(ctx, *mTx)
+		}
+	} else {
+		fmt.Println("signed tx already found in the network")
 	}
+
+	return signedTx, nil
+}
+
+// exceedsMonitoringLimits checks if the monitored tx reached the configured max history size
+// or max age, and returns the reason to mark it as failed if so
+func (c *Client) exceedsMonitoringLimits(mTx monitoredTx) (string, bool) {
+	if c.cfg.MaxHistorySize > 0 && uint64(len(mTx.history)) >= c.cfg.MaxHistorySize {
+		return fmt.Sprintf("reached the max history size limit of %v txs", c.cfg.MaxHistorySize), true
+	}
+	if c.cfg.MaxMonitoredTxAge.Duration > 0 && time.Since(mTx.createdAt) > c.cfg.MaxMonitoredTxAge.Duration {
+		return fmt.Sprintf("reached the max monitored tx age of %v", c.cfg.MaxMonitoredTxAge.Duration), true
+	}
+	return "", false
+}
+
+// revertReason returns the reason to mark the monitored tx as failed when the tx was
+// mined but reverted
+func (c *Client) revertReason(ctx context.Context, txHash common.Hash) string {
+	tx, _, err := c.etherman.GetTx(ctx, txHash)
+	if err != nil {
+		return fmt.Sprintf("tx %v reverted", txHash.String())
+	}
+	revertMessage, err := c.etherman.GetRevertMessage(ctx, tx)
+	if err != nil || revertMessage == "" {
+		return fmt.Sprintf("tx %v reverted", txHash.String())
+	}
+	return fmt.Sprintf("tx %v reverted: %v", txHash.String(), revertMessage)
+}
+
+// minedStatus returns the status of the monitored tx once the tx identified by the hash was
+// mined successfully. A canceling monitored tx is only canceled if the cancel tx was mined
+// instead of one of the txs sent before the cancellation.
+func (c *Client) minedStatus(ctx context.Context, mTx monitoredTx, txHash common.Hash) MonitoredTxStatus {
+	if mTx.status != MonitoredTxStatusCanceling {
+		return MonitoredTxStatusConfirmed
+	}
+	tx, _, err := c.etherman.GetTx(ctx, txHash)
+	if err != nil {
+		fmt.Printf("failed to get mined tx %v of canceling monitored tx: %v\n", txHash.String(), err)
+		return MonitoredTxStatusCanceled
+	}
+	if isCancelTx(mTx.from, tx) {
+		return MonitoredTxStatusCanceled
+	}
+	return MonitoredTxStatusConfirmed
 }
 
 // shouldContinueToMonitorThisTx checks the the tx receipt and decides if it should
@@ -540,8 +718,13 @@ func (c *Client) reviewMonitoredTxNonce(ctx context.Context, mTx *monitoredTx) e
 		return err
 	}
 	if nonce > mTx.nonce {
-		fmt.Printf("monitored tx nonce updated from %v to %v", mTx.nonce, nonce)
-		mTx.nonce = nonce
+		// the nonce was consumed, so the next nonce of the sender is taken from the nonce
+		// manager to not reuse the nonce of another pending monitored tx
+		return c.nonces.reserve(ctx, mTx.from, func(nonce uint64) error {
+			fmt.Printf("monitored tx nonce updated from %v to %v\n", mTx.nonce, nonce)
+			mTx.nonce = nonce
+			return nil
+		})
 	}
 	return nil
 }
@@ -575,6 +758,29 @@ func (c *Client) reviewMonitoredTx(ctx context.Context, mTx *monitoredTx) error
 		fmt.Printf("monitored tx gas price updated from %v to %v\n", mTx.gasPrice.String(), gasPrice.String())
 		mTx.gasPrice = gasPrice
 	}
+
+	// check gas tip cap and blob gas price
+	if mTx.isBlobTx() {
+		gasTipCap, err := c.suggestedGasTipCap(ctx, mTx.gasPrice)
+		if err != nil {
+			err := fmt.Errorf("failed to get suggested gas tip cap: %w", err)
+			return err
+		}
+		if mTx.gasTipCap == nil || gasTipCap.Cmp(mTx.gasTipCap) == 1 {
+			fmt.Printf("monitored tx gas tip cap updated from %v to %v\n", mTx.gasTipCap, gasTipCap.String())
+			mTx.gasTipCap = gasTipCap
+		}
+
+		blobGasPrice, err := c.suggestedBlobGasPrice(ctx)
+		if err != nil {
+			err := fmt.Errorf("failed to get suggested blob gas price: %w", err)
+			return err
+		}
+		if blobGasPrice.Cmp(mTx.blobGasPrice) == 1 {
+			fmt.Printf("monitored tx blob gas price updated from %v to %v\n", mTx.blobGasPrice.String(), blobGasPrice.String())
+			mTx.blobGasPrice = blobGasPrice
+		}
+	}
 	return nil
 }
 
@@ -602,6 +808,59 @@ func (c *Client) suggestedGasPrice(ctx context.Context) (*big.Int, error) {
 	return adjustedGasPrice, nil
 }
 
+// suggestedGasTipCap returns the max priority fee per gas of the txs sent with the gas price as
+// their max fee per gas, which it does not exceed
+func (c *Client) suggestedGasTipCap(ctx context.Context, gasPrice *big.Int) (*big.Int, error) {
+	// get gas tip cap
+	gasTipCap, err := c.etherman.SuggestedGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// adjust the gas tip cap by the margin factor
+	marginFactor := big.NewFloat(0).SetFloat64(c.cfg.GasPriceMarginFactor)
+	fGasTipCap := big.NewFloat(0).SetInt(gasTipCap)
+	adjustedGasTipCap, _ := big.NewFloat(0).Mul(fGasTipCap, marginFactor).Int(big.NewInt(0))
+
+	// the tip is part of the max fee per gas
+	if adjustedGasTipCap.Cmp(gasPrice) == 1 {
+		adjustedGasTipCap.Set(gasPrice)
+	}
+
+	return adjustedGasTipCap, nil
+}
+
+func (c *Client) suggestedBlobGasPrice(ctx context.Context) (*big.Int, error) {
+	// get blob gas price
+	blobGasPrice, err := c.etherman.SuggestedBlobGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// adjust the blob gas price by the margin factor
+	marginFactor := big.NewFloat(0).SetFloat64(c.cfg.GasPriceMarginFactor)
+	fBlobGasPrice := big.NewFloat(0).SetInt(blobGasPrice)
+	adjustedBlobGasPrice, _ := big.NewFloat(0).Mul(fBlobGasPrice, marginFactor).Int(big.NewInt(0))
+
+	// blob txs can not be sent with a zero max fee per blob gas
+	if adjustedBlobGasPrice.Sign() == 0 {
+		adjustedBlobGasPrice.SetUint64(1)
+	}
+
+	return adjustedBlobGasPrice, nil
+}
+
+// processedStatuses are the statuses of the monitored txs processed for their owner, either
+// pending or waiting for their result to be handled
+var processedStatuses = []MonitoredTxStatus{
+	MonitoredTxStatusCreated,
+	MonitoredTxStatusSent,
+	MonitoredTxStatusCanceling,
+	MonitoredTxStatusFailed,
+	MonitoredTxStatusCanceled,
+	MonitoredTxStatusConfirmed,
+}
+
 // ResultHandler used by the caller to handle results when processing monitored txs
 type ResultHandler func(MonitoredTxResult)
 
@@ -610,67 +869,130 @@ type ResultHandler func(MonitoredTxResult)
 //
 // For the confirmed and failed ones, the resultHandler will be triggered
 func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, owner string, resultHandler ResultHandler) {
-	statusesFilter := []MonitoredTxStatus{
-		MonitoredTxStatusCreated,
-		MonitoredTxStatusSent,
-		MonitoredTxStatusFailed,
-		MonitoredTxStatusConfirmed,
-	}
 	// Keep running until there are no pending monitored txs
 	for {
-		results, err := c.ResultsByStatus(ctx, owner, statusesFilter)
-		if err != nil {
-			// If something goes wrong here, we log and wait for abit and keep it in the infinite loop to not
-			// unlock the caller.
-			fmt.Printf("failed to get results by statuses from eth tx manager to monitored txs err: %v\n", err)
-			time.Sleep(time.Second)
-			continue
+		if c.processPendingMonitoredTxs(ctx, owner, resultHandler) {
+			return
 		}
+	}
+}
 
-		if len(results) == 0 {
-			// if there are not pending monitored txs, stop
-			return
+// ProcessMonitoredTxs triggers the resultHandler for the confirmed, failed and canceled monitored
+// txs of this owner like ProcessPendingMonitoredTxs, but without waiting for the pending ones.
+// It returns the number of monitored txs still pending.
+func (c *Client) ProcessMonitoredTxs(ctx context.Context, owner string, resultHandler ResultHandler) (int, error) {
+	results, err := c.ResultsByStatus(ctx, owner, processedStatuses)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get results by statuses from eth tx manager to monitored txs: %w", err)
+	}
+
+	pending := 0
+	for _, result := range results {
+		handled, err := c.handlePendingResult(ctx, owner, result, resultHandler)
+		if err != nil {
+			return 0, fmt.Errorf("failed to set monitored tx as done: %w", err)
+		}
+		if !handled {
+			pending++
 		}
+	}
+	return pending, nil
+}
 
+// processPendingMonitoredTxs subscribes to the results of the owner and handles the pending
+// monitored txs as their status changes. It returns false if it needs to start over, when the
+// results can not be loaded or the subscription fell behind.
+func (c *Client) processPendingMonitoredTxs(ctx context.Context, owner string, resultHandler ResultHandler) bool {
+	// Subscribe before loading the current results to not miss any status change
+	sub := c.Subscribe(owner)
+	defer sub.Unsubscribe()
+
+	results, err := c.ResultsByStatus(ctx, owner, processedStatuses)
+	if err != nil {
+		// If something goes wrong here, we log and wait for abit and keep it in the infinite loop to not
+		// unlock the caller.
+		fmt.Printf("failed to get results by statuses from eth tx manager to monitored txs err: %v\n", err)
+		time.Sleep(time.Second)
+		return false
+	}
+
+	pending := map[string]bool{}
+	for _, result := range results {
+		pending[result.ID] = true
+	}
+
+	for {
 		for _, result := range results {
-			// If the result is confirmed, we set it as done. We do not stop looking into the current
-			// monitored tx
-			if result.Status == MonitoredTxStatusConfirmed {
-				err := c.setStatusDone(ctx, owner, result.ID)
-				if err != nil {
-					fmt.Printf("failed to set monitored tx as done, err: %v\n", err)
-					continue
-				} else {
-					fmt.Println("monitored tx confirmed")
-				}
-				resultHandler(result)
+			if !pending[result.ID] {
 				continue
 			}
-
-			// If the result is failed, we need to go around it and rebuild a batch verification
-			if result.Status == MonitoredTxStatusFailed {
-				resultHandler(result)
-				continue
+			handled, err := c.handlePendingResult(ctx, owner, result, resultHandler)
+			if err != nil {
+				fmt.Printf("failed to set monitored tx as done, err: %v\n", err)
+				time.Sleep(time.Second)
+				return false
+			}
+			if handled {
+				delete(pending, result.ID)
 			}
+		}
 
-			// If the result is neither confirmed or failed, it means we need to wait until it gets
-			// confirmed or failed.
-			for {
-				// wait before refreshing the result info
-				time.Sleep(time.Second)
+		// if there are not pending monitored txs, stop
+		if len(pending) == 0 {
+			return true
+		}
 
-				// refresh the result info
-				result, err := c.Result(ctx, owner, result.ID)
-				if err != nil {
-					fmt.Printf("failed to get monitored tx result, err: %v\n", err)
-					continue
-				}
-				// if the result status is confirmed or failed, breaks the wait loop
-				if result.Status == MonitoredTxStatusConfirmed || result.Status == MonitoredTxStatusFailed {
-					break
-				}
-				fmt.Printf("waiting for monitored tx to get confirmed, status: %v\n", result.Status.String())
+		// If the results are neither confirmed or failed, it means we need to wait until they
+		// get confirmed or failed.
+		select {
+		case <-ctx.Done():
+			return true
+		case result, ok := <-sub.Results():
+			if !ok {
+				fmt.Println("results subscription closed, loading the pending monitored txs again")
+				return false
 			}
+			// the monitored txs added while waiting are also waited for
+			if result.Status == MonitoredTxStatusCreated || result.Status == MonitoredTxStatusSent ||
+				result.Status == MonitoredTxStatusCanceling {
+				pending[result.ID] = true
+			}
+			if !pending[result.ID] {
+				continue
+			}
+			fmt.Printf("monitored tx %v status changed to %v\n", result.ID, result.Status.String())
+			results = []MonitoredTxResult{result}
 		}
 	}
 }
+
+// handlePendingResult triggers the resultHandler for the confirmed, failed and canceled
+// results, and returns if the result was handled
+func (c *Client) handlePendingResult(ctx context.Context, owner string, result MonitoredTxResult, resultHandler ResultHandler) (bool, error) {
+	switch result.Status {
+	// If the result is confirmed, we set it as done do stop looking into this monitored tx
+	case MonitoredTxStatusConfirmed:
+		err := c.setStatusDone(ctx, owner, result.ID)
+		if err != nil {
+			return false, err
+		}
+		fmt.Println("monitored tx confirmed")
+		resultHandler(result)
+		return true, nil
+
+	// If the result is failed or canceled, we need to go around it and rebuild a batch
+	// verification. A failed or canceled monitored tx is terminal, so it is set as done
+	// once it is handled to not process it again, while keeping its failure reason
+	case MonitoredTxStatusFailed, MonitoredTxStatusCanceled:
+		fmt.Printf("monitored tx %v %v: %v\n", result.ID, result.Status, result.FailureReason)
+		resultHandler(result)
+		err := c.setStatusDone(ctx, owner, result.ID)
+		if err != nil {
+			fmt.Printf("failed to set %v monitored tx as done, err: %v\n", result.Status, err)
+		}
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,153 @@
+package ethtxmanager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOwner = "owner"
+
+func newTestingClient(t *testing.T, cfg Config) (*Client, *fakeEtherman) {
+	t.Helper()
+	etherman := newFakeEtherman()
+	cfg.GasPriceMarginFactor = 1
+	return newClient(cfg, etherman, NewMonitoredTxsStorage()), etherman
+}
+
+func TestMonitorTxFailsWhenMaxHistorySizeIsReached(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{MaxHistorySize: 2})
+	ctx := context.Background()
+
+	to := common.HexToAddress("0x2")
+	require.NoError(t, c.Add(ctx, testOwner, "id", etherman.address(), &to, nil, nil, 0))
+
+	// Each monitoring cycle resubmits the tx with a higher gas price
+	for i := 0; i < 2; i++ {
+		require.NoError(t, c.monitorTxs(ctx))
+		etherman.increaseGasPrice()
+
+		result, err := c.Result(ctx, testOwner, "id")
+		require.NoError(t, err)
+		assert.Equal(t, MonitoredTxStatusSent, result.Status)
+		assert.Len(t, result.Txs, i+1)
+	}
+
+	// The next cycle reaches the limit and stops resubmitting the tx
+	require.NoError(t, c.monitorTxs(ctx))
+	result, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusFailed, result.Status)
+	assert.Len(t, result.Txs, 2)
+	assert.Contains(t, result.FailureReason, "max history size")
+	assert.Len(t, etherman.sent, 2)
+
+	// The failure reason is reported to the pending monitored txs handler
+	var results []MonitoredTxResult
+	c.ProcessPendingMonitoredTxs(ctx, testOwner, func(result MonitoredTxResult) {
+		results = append(results, result)
+	})
+	require.Len(t, results, 1)
+	assert.Equal(t, MonitoredTxStatusFailed, results[0].Status)
+	assert.Equal(t, result.FailureReason, results[0].FailureReason)
+
+	// The failed monitored tx is not processed again, but keeps its failure reason
+	result, err = c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusDone, result.Status)
+	assert.Equal(t, results[0].FailureReason, result.FailureReason)
+}
+
+func TestMonitorTxFailsWhenMaxAgeIsReached(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{MaxMonitoredTxAge: types.NewDuration(time.Minute)})
+	ctx := context.Background()
+
+	to := common.HexToAddress("0x2")
+	require.NoError(t, c.Add(ctx, testOwner, "id", etherman.address(), &to, nil, nil, 0))
+	require.NoError(t, c.monitorTxs(ctx))
+
+	// Age the monitored tx over the limit
+	mTx, err := c.storage.Get(ctx, testOwner, "id")
+	require.NoError(t, err)
+	mTx.createdAt = time.Now().Add(-2 * time.Minute)
+	require.NoError(t, c.storage.Update(ctx, mTx))
+
+	require.NoError(t, c.monitorTxs(ctx))
+	result, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusFailed, result.Status)
+	assert.Contains(t, result.FailureReason, "max monitored tx age")
+}
+
+func TestMonitorTxConfirmedBeforeLimitIsReached(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{MaxHistorySize: 1})
+	etherman.autoMine = true
+	ctx := context.Background()
+
+	// The only tx of the history gets mined, so the limit does not apply
+	to := common.HexToAddress("0x2")
+	require.NoError(t, c.Add(ctx, testOwner, "id", etherman.address(), &to, nil, nil, 0))
+	require.NoError(t, c.monitorTxs(ctx))
+
+	result, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusConfirmed, result.Status)
+	assert.Empty(t, result.FailureReason)
+}
+
+func TestAddBlobSetsGasTipCap(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	etherman.gasPrice = big.NewInt(10) //nolint:gomnd
+	etherman.gasTipCap = big.NewInt(2) //nolint:gomnd
+
+	to := common.HexToAddress("0x2")
+	sidecar := &ethTypes.BlobTxSidecar{Blobs: []kzg4844.Blob{{}}, Commitments: []kzg4844.Commitment{{}}, Proofs: []kzg4844.Proof{{}}}
+	require.NoError(t, c.AddBlob(ctx, testOwner, "id", etherman.address(), &to, sidecar, 0))
+	mTx, err := c.storage.Get(ctx, testOwner, "id")
+	require.NoError(t, err)
+	tx := mTx.Tx()
+	assert.Equal(t, big.NewInt(2), tx.GasTipCap())
+	assert.Equal(t, big.NewInt(10), tx.GasFeeCap())
+
+	// The tip never exceeds the max fee per gas
+	etherman.gasTipCap = big.NewInt(20) //nolint:gomnd
+	require.NoError(t, c.reviewMonitoredTx(ctx, &mTx))
+	assert.Equal(t, big.NewInt(10), mTx.Tx().GasTipCap())
+}
+
+func TestStopInterruptsWaitingTxToBeMined(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{WaitTxToBeMined: types.NewDuration(time.Hour)})
+	ctx := context.Background()
+	to := common.HexToAddress("0x2")
+	require.NoError(t, c.Add(ctx, testOwner, "id", etherman.address(), &to, nil, nil, 0))
+
+	etherman.waiting = make(chan struct{})
+	etherman.release = make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		c.Start(ctx)
+		close(stopped)
+	}()
+	<-etherman.waiting
+
+	// Stop returns once Start returned, and the sent tx is kept to be resumed
+	c.Stop()
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("eth tx manager stopped before Start returned")
+	}
+	result, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusSent, result.Status)
+	assert.Len(t, result.Txs, 1)
+}
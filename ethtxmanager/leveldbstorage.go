@@ -0,0 +1,212 @@
+package ethtxmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// monitoredTxKeyPrefix is the prefix of the keys used to store the monitored txs
+var monitoredTxKeyPrefix = []byte("monitoredtx-")
+
+// LevelDBStorage is a durable storage of the monitored txs backed by an embedded
+// LevelDB database, so the monitored txs survive node restarts.
+type LevelDBStorage struct {
+	db    *leveldb.DB
+	mutex *sync.Mutex
+}
+
+// NewLevelDBStorage creates a new instance of the durable storage, opening or
+// creating the LevelDB database at the provided path
+func NewLevelDBStorage(path string) (*LevelDBStorage, error) {
+	if path == "" {
+		return nil, errors.New("monitored txs storage path not configured")
+	}
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDBStorage{db: db, mutex: &sync.Mutex{}}, nil
+}
+
+// Get loads the persisted monitored tx matching the owner and id
+func (s *LevelDBStorage) Get(ctx context.Context, owner, id string) (monitoredTx, error) {
+	raw, err := s.db.Get(monitoredTxDBKey(owner, id), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return monitoredTx{}, ErrNotFound
+	} else if err != nil {
+		return monitoredTx{}, err
+	}
+	return decodeMonitoredTx(raw)
+}
+
+// GetByStatus loads all persisted monitored txs of the owner that match the provided
+// statuses, ordered by created at ascending. If the owner is nil, the monitored txs of
+// all the owners are considered. If the statuses are empty, all the statuses are considered.
+func (s *LevelDBStorage) GetByStatus(ctx context.Context, owner *string, statusesFilter []MonitoredTxStatus) ([]monitoredTx, error) {
+	prefix := monitoredTxKeyPrefix
+	if owner != nil {
+		prefix = monitoredTxDBKey(*owner, "")
+	}
+	iter := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	mTxs := []monitoredTx{}
+	for iter.Next() {
+		mTx, err := decodeMonitoredTx(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if mTx.matches(owner, statusesFilter) {
+			mTxs = append(mTxs, mTx)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	sortByCreatedAt(mTxs)
+	return mTxs, nil
+}
+
+// Add persists a new monitored tx
+func (s *LevelDBStorage) Add(ctx context.Context, mTx monitoredTx) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	found, err := s.db.Has(monitoredTxDBKey(mTx.owner, mTx.id), nil)
+	if err != nil {
+		return err
+	} else if found {
+		return ErrAlreadyExists
+	}
+	return s.put(mTx)
+}
+
+// Update replaces a persisted monitored tx, refreshing its updated at
+func (s *LevelDBStorage) Update(ctx context.Context, mTx monitoredTx) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	found, err := s.db.Has(monitoredTxDBKey(mTx.owner, mTx.id), nil)
+	if err != nil {
+		return err
+	} else if !found {
+		return ErrNotFound
+	}
+	mTx.updatedAt = time.Now()
+	return s.put(mTx)
+}
+
+// Close closes the underlying database
+func (s *LevelDBStorage) Close() error {
+	return s.db.Close()
+}
+
+func (s *LevelDBStorage) put(mTx monitoredTx) error {
+	raw, err := encodeMonitoredTx(mTx)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(monitoredTxDBKey(mTx.owner, mTx.id), raw, nil)
+}
+
+// monitoredTxDBKey builds the key of a monitored tx. The owner is null terminated,
+// so the keys of an owner never collide with the ones of an owner sharing its prefix.
+func monitoredTxDBKey(owner, id string) []byte {
+	key := make([]byte, 0, len(monitoredTxKeyPrefix)+len(owner)+1+len(id))
+	key = append(key, monitoredTxKeyPrefix...)
+	key = append(key, owner...)
+	key = append(key, 0)
+	return append(key, id...)
+}
+
+// monitoredTxRecord is the serialized form of a monitored tx, with all the fields
+// needed to rebuild and keep monitoring the tx after a restart
+type monitoredTxRecord struct {
+	Owner         string               `json:"owner"`
+	ID            string               `json:"id"`
+	From          common.Address       `json:"from"`
+	To            *common.Address      `json:"to,omitempty"`
+	Nonce         uint64               `json:"nonce"`
+	Value         *big.Int             `json:"value,omitempty"`
+	Data          hexutil.Bytes        `json:"data,omitempty"`
+	Gas           uint64               `json:"gas"`
+	GasOffset     uint64               `json:"gasOffset"`
+	GasPrice      *big.Int             `json:"gasPrice"`
+	BlobSidecar   *types.BlobTxSidecar `json:"blobSidecar,omitempty"`
+	BlobGasPrice  *big.Int             `json:"blobGasPrice,omitempty"`
+	GasTipCap     *big.Int             `json:"gasTipCap,omitempty"`
+	Status        MonitoredTxStatus    `json:"status"`
+	FailureReason string               `json:"failureReason,omitempty"`
+	BlockNumber   *big.Int             `json:"blockNumber,omitempty"`
+	History       []common.Hash        `json:"history"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+}
+
+func encodeMonitoredTx(mTx monitoredTx) ([]byte, error) {
+	return json.Marshal(monitoredTxRecord{
+		Owner:         mTx.owner,
+		ID:            mTx.id,
+		From:          mTx.from,
+		To:            mTx.to,
+		Nonce:         mTx.nonce,
+		Value:         mTx.value,
+		Data:          mTx.data,
+		Gas:           mTx.gas,
+		GasOffset:     mTx.gasOffset,
+		GasPrice:      mTx.gasPrice,
+		BlobSidecar:   mTx.blobSidecar,
+		BlobGasPrice:  mTx.blobGasPrice,
+		GasTipCap:     mTx.gasTipCap,
+		Status:        mTx.status,
+		FailureReason: mTx.failureReason,
+		BlockNumber:   mTx.blockNumber,
+		History:       mTx.historyHashSlice(),
+		CreatedAt:     mTx.createdAt,
+		UpdatedAt:     mTx.updatedAt,
+	})
+}
+
+func decodeMonitoredTx(raw []byte) (monitoredTx, error) {
+	var r monitoredTxRecord
+	err := json.Unmarshal(raw, &r)
+	if err != nil {
+		return monitoredTx{}, err
+	}
+
+	history := make(map[common.Hash]bool, len(r.History))
+	for _, h := range r.History {
+		history[h] = true
+	}
+	return monitoredTx{
+		owner:         r.Owner,
+		id:            r.ID,
+		from:          r.From,
+		to:            r.To,
+		nonce:         r.Nonce,
+		value:         r.Value,
+		data:          r.Data,
+		gas:           r.Gas,
+		gasOffset:     r.GasOffset,
+		gasPrice:      r.GasPrice,
+		blobSidecar:   r.BlobSidecar,
+		blobGasPrice:  r.BlobGasPrice,
+		gasTipCap:     r.GasTipCap,
+		status:        r.Status,
+		failureReason: r.FailureReason,
+		blockNumber:   r.BlockNumber,
+		history:       history,
+		createdAt:     r.CreatedAt,
+		updatedAt:     r.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package ethtxmanager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelDBStorageSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	storage, err := NewLevelDBStorage(path)
+	require.NoError(t, err)
+
+	to := common.HexToAddress("0x2")
+	createdAt := time.Now().Add(-time.Minute).UTC().Round(time.Microsecond)
+	mTx := monitoredTx{
+		owner: "owner", id: "id", from: common.HexToAddress("0x1"), to: &to,
+		nonce: 1, value: big.NewInt(2), data: []byte("data"),
+		gas: 3, gasOffset: 4, gasPrice: big.NewInt(5),
+		status:      MonitoredTxStatusSent,
+		blockNumber: big.NewInt(6),
+		history:     map[common.Hash]bool{common.HexToHash("0x3"): true, common.HexToHash("0x4"): true},
+		createdAt:   createdAt,
+		updatedAt:   createdAt.Add(time.Second),
+	}
+	require.NoError(t, storage.Add(ctx, mTx))
+
+	confirmed := mTx
+	confirmed.id = "confirmed"
+	confirmed.status = MonitoredTxStatusConfirmed
+	confirmed.history = map[common.Hash]bool{}
+	require.NoError(t, storage.Add(ctx, confirmed))
+
+	// Reopen the storage, as done on node restart
+	require.NoError(t, storage.Close())
+	storage, err = NewLevelDBStorage(path)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	returnedMtx, err := storage.Get(ctx, "owner", "id")
+	require.NoError(t, err)
+	assert.Equal(t, mTx.owner, returnedMtx.owner)
+	assert.Equal(t, mTx.id, returnedMtx.id)
+	assert.Equal(t, mTx.from, returnedMtx.from)
+	assert.Equal(t, mTx.to, returnedMtx.to)
+	assert.Equal(t, mTx.nonce, returnedMtx.nonce)
+	assert.Equal(t, mTx.value, returnedMtx.value)
+	assert.Equal(t, mTx.data, returnedMtx.data)
+	assert.Equal(t, mTx.gas, returnedMtx.gas)
+	assert.Equal(t, mTx.gasOffset, returnedMtx.gasOffset)
+	assert.Equal(t, mTx.gasPrice, returnedMtx.gasPrice)
+	assert.Equal(t, mTx.status, returnedMtx.status)
+	assert.Equal(t, mTx.blockNumber, returnedMtx.blockNumber)
+	assert.Equal(t, mTx.history, returnedMtx.history)
+	assert.True(t, mTx.createdAt.Equal(returnedMtx.createdAt))
+	assert.True(t, mTx.updatedAt.Equal(returnedMtx.updatedAt))
+
+	// Only the pending monitored tx is resumed
+	pending, err := storage.GetByStatus(ctx, nil, []MonitoredTxStatus{MonitoredTxStatusCreated, MonitoredTxStatusSent})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "id", pending[0].id)
+
+	_, err = storage.Get(ctx, "owner", "unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStartResumesPendingTxsFromLevelDB(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+	etherman := newFakeEtherman()
+	cfg := Config{GasPriceMarginFactor: 1, FrequenceToMonitorTxs: types.NewDuration(time.Hour)}
+	to := common.HexToAddress("0x2")
+
+	// One monitored tx is sent and the other one only created before the node stops
+	storage, err := NewLevelDBStorage(path)
+	require.NoError(t, err)
+	c := newClient(cfg, etherman, storage)
+	require.NoError(t, c.Add(ctx, testOwner, "sent", etherman.address(), &to, nil, []byte{1}, 0))
+	require.NoError(t, c.monitorTxs(ctx))
+	sent := etherman.lastSent()
+	require.NoError(t, c.Add(ctx, testOwner, "created", etherman.address(), &to, nil, []byte{2}, 0))
+	c.Stop()
+
+	// Both are resumed on start from the reopened storage, without waiting for a monitoring cycle
+	storage, err = NewLevelDBStorage(path)
+	require.NoError(t, err)
+	c = newClient(cfg, etherman, storage)
+	etherman.mine(sent)
+	etherman.autoMine = true
+	startCtx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	go func() {
+		c.Start(startCtx)
+		close(stopped)
+	}()
+	defer func() {
+		cancel()
+		<-stopped
+		c.Stop()
+	}()
+
+	for _, id := range []string{"sent", "created"} {
+		require.Eventually(t, func() bool {
+			result, err := c.Result(ctx, testOwner, id)
+			return err == nil && result.Status == MonitoredTxStatusConfirmed
+		}, 5*time.Second, 10*time.Millisecond, id) //nolint:gomnd
+	}
+}
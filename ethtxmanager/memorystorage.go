@@ -0,0 +1,100 @@
+package ethtxmanager
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// monitoredTxKey identifies a monitored tx, ids are only unique per owner
+type monitoredTxKey struct {
+	owner string
+	id    string
+}
+
+// MonitoredTxsStorage is the in-memory storage of the monitored txs. Its content
+// is lost when the node stops. The monitored txs are copied in and out of it, so the
+// callers never share them with each other.
+type MonitoredTxsStorage struct {
+	inner map[monitoredTxKey]monitoredTx
+	mutex *sync.RWMutex
+}
+
+// NewMonitoredTxsStorage creates a new instance of the in-memory storage
+func NewMonitoredTxsStorage() *MonitoredTxsStorage {
+	return &MonitoredTxsStorage{
+		inner: map[monitoredTxKey]monitoredTx{},
+		mutex: &sync.RWMutex{},
+	}
+}
+
+// Get loads the monitored tx matching the owner and id
+func (s *MonitoredTxsStorage) Get(ctx context.Context, owner, id string) (monitoredTx, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	mTx, ok := s.inner[monitoredTxKey{owner: owner, id: id}]
+	if !ok {
+		return monitoredTx{}, ErrNotFound
+	}
+	return mTx.clone(), nil
+}
+
+// GetByStatus loads all monitored txs of the owner that match the provided statuses,
+// ordered by created at ascending. If the owner is nil, the monitored txs of all the
+// owners are considered. If the statuses are empty, all the statuses are considered.
+func (s *MonitoredTxsStorage) GetByStatus(ctx context.Context, owner *string, statusesFilter []MonitoredTxStatus) ([]monitoredTx, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	mTxs := []monitoredTx{}
+	for _, mTx := range s.inner {
+		if mTx.matches(owner, statusesFilter) {
+			mTxs = append(mTxs, mTx.clone())
+		}
+	}
+	sortByCreatedAt(mTxs)
+	return mTxs, nil
+}
+
+// Add stores a new monitored tx
+func (s *MonitoredTxsStorage) Add(ctx context.Context, mTx monitoredTx) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := monitoredTxKey{owner: mTx.owner, id: mTx.id}
+	if _, found := s.inner[key]; found {
+		return ErrAlreadyExists
+	}
+	s.inner[key] = mTx.clone()
+	return nil
+}
+
+// Update replaces a stored monitored tx, refreshing its updated at
+func (s *MonitoredTxsStorage) Update(ctx context.Context, mTx monitoredTx) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := monitoredTxKey{owner: mTx.owner, id: mTx.id}
+	if _, found := s.inner[key]; !found {
+		return ErrNotFound
+	}
+	mTx.updatedAt = time.Now()
+	s.inner[key] = mTx.clone()
+	return nil
+}
+
+// sortByCreatedAt sorts the monitored txs by created at ascending. Monitored txs
+// created at the same time are sorted by owner and id, so the order is deterministic.
+func sortByCreatedAt(mTxs []monitoredTx) {
+	sort.SliceStable(mTxs, func(i, j int) bool {
+		if !mTxs[i].createdAt.Equal(mTxs[j].createdAt) {
+			return mTxs[i].createdAt.Before(mTxs[j].createdAt)
+		}
+		if mTxs[i].owner != mTxs[j].owner {
+			return mTxs[i].owner < mTxs[j].owner
+		}
+		return mTxs[i].id < mTxs[j].id
+	})
+}
@@ -0,0 +1,53 @@
+package ethtxmanager
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/sieniven/zkevm-nubit/log"
+)
+
+// migrationsTableName is the table used to track the applied migrations. It must
+// differ from the zkevm-node one, as both nodes can share the same database.
+const migrationsTableName = "ethtxmanager_gorp_migrations"
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// RunMigrationsUp runs the monitored txs storage migrations up for the given config
+func RunMigrationsUp(cfg db.Config) error {
+	log.Info("running ethtxmanager migrations up")
+	return runMigrations(cfg, migrate.Up)
+}
+
+// RunMigrationsDown runs the monitored txs storage migrations down for the given config
+func RunMigrationsDown(cfg db.Config) error {
+	log.Info("running ethtxmanager migrations down")
+	return runMigrations(cfg, migrate.Down)
+}
+
+// runMigrations will execute pending migrations if needed to keep
+// the database updated with the latest changes in either direction,
+// up or down.
+func runMigrations(cfg db.Config, direction migrate.MigrationDirection) error {
+	c, err := pgx.ParseConfig(fmt.Sprintf("postgres://%s:%s@%s:%s/%s", cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name))
+	if err != nil {
+		return err
+	}
+	conn := stdlib.OpenDB(*c)
+	defer conn.Close()
+
+	migrationSet := migrate.MigrationSet{TableName: migrationsTableName}
+	migrations := &migrate.EmbedFileSystemMigrationSource{FileSystem: migrationsFS, Root: "migrations"}
+	nMigrations, err := migrationSet.Exec(conn, "postgres", migrations, direction)
+	if err != nil {
+		return err
+	}
+
+	log.Info("successfully ran ", nMigrations, " migrations")
+	return nil
+}
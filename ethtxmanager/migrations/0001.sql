@@ -0,0 +1,28 @@
+-- +migrate Up
+-- The monitored txs table follows the zkevm-node state.monitored_txs schema, so the
+-- mock node can share the database of a real zkevm-node. The statements are no-ops
+-- when the zkevm-node migrations already created the table.
+CREATE SCHEMA IF NOT EXISTS state;
+
+CREATE TABLE IF NOT EXISTS state.monitored_txs
+(
+    owner      VARCHAR NOT NULL,
+    id         VARCHAR NOT NULL,
+    from_addr  VARCHAR NOT NULL,
+    to_addr    VARCHAR,
+    nonce      DECIMAL(78, 0) NOT NULL,
+    value      DECIMAL(78, 0),
+    data       VARCHAR,
+    gas        DECIMAL(78, 0) NOT NULL,
+    gas_offset DECIMAL(78, 0) NOT NULL,
+    gas_price  DECIMAL(78, 0) NOT NULL,
+    status     VARCHAR NOT NULL,
+    history    VARCHAR[],
+    block_num  BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (owner, id)
+);
+
+-- +migrate Down
+-- The table is shared with the zkevm-node, so it is not dropped
@@ -0,0 +1,21 @@
+-- +migrate Up
+-- The monitored txs fields unknown to the zkevm-node are kept in a table of their own, out
+-- of the state schema, so the shared state.monitored_txs table keeps the zkevm-node schema.
+-- The monitored txs added by the zkevm-node have no row in it.
+CREATE SCHEMA IF NOT EXISTS ethtxmanager;
+
+CREATE TABLE IF NOT EXISTS ethtxmanager.monitored_txs_ext
+(
+    owner          VARCHAR NOT NULL,
+    id             VARCHAR NOT NULL,
+    blob_sidecar   JSONB,
+    blob_gas_price DECIMAL(78, 0),
+    gas_tip_cap    DECIMAL(78, 0),
+    failure_reason VARCHAR,
+    PRIMARY KEY (owner, id),
+    FOREIGN KEY (owner, id) REFERENCES state.monitored_txs (owner, id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS ethtxmanager.monitored_txs_ext;
+DROP SCHEMA IF EXISTS ethtxmanager;
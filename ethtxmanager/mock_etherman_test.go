@@ -0,0 +1,173 @@
+package ethtxmanager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fakeEtherman is an in-memory L1 where the sent txs are never mined, unless
+// autoMine is set
+type fakeEtherman struct {
+	mutex    sync.Mutex
+	autoMine bool
+	key      *ecdsa.PrivateKey
+	chainID  *big.Int
+	nonce    uint64
+	gasPrice *big.Int
+	// gasTipCap is the suggested priority fee of the blob txs
+	gasTipCap *big.Int
+	sent      map[common.Hash]*types.Transaction
+	receipts  map[common.Hash]*types.Receipt
+	// sentOrder keeps the hashes of the sent txs in the order they were sent
+	sentOrder []common.Hash
+	// if waiting is set, WaitTxToBeMined signals on it and blocks until release is closed or
+	// the context is done
+	waiting chan struct{}
+	release chan struct{}
+}
+
+func newFakeEtherman() *fakeEtherman {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+	return &fakeEtherman{
+		key:       key,
+		chainID:   big.NewInt(1337), //nolint:gomnd
+		gasPrice:  big.NewInt(1),
+		gasTipCap: big.NewInt(1),
+		sent:      map[common.Hash]*types.Transaction{},
+		receipts:  map[common.Hash]*types.Receipt{},
+	}
+}
+
+func (e *fakeEtherman) address() common.Address {
+	return crypto.PubkeyToAddress(e.key.PublicKey)
+}
+
+// increaseGasPrice makes the next suggested gas price higher, so the reviewed
+// monitored tx gets resubmitted with a new hash
+func (e *fakeEtherman) increaseGasPrice() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.gasPrice = new(big.Int).Add(e.gasPrice, big.NewInt(1))
+}
+
+// lastSent returns the last tx sent to the network
+func (e *fakeEtherman) lastSent() *types.Transaction {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if len(e.sentOrder) == 0 {
+		return nil
+	}
+	return e.sent[e.sentOrder[len(e.sentOrder)-1]]
+}
+
+// mine mines the tx successfully and consumes its nonce
+func (e *fakeEtherman) mine(tx *types.Transaction) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.receipts[tx.Hash()] = &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash(), BlockNumber: big.NewInt(1)}
+	e.nonce = tx.Nonce() + 1
+}
+
+func (e *fakeEtherman) GetTx(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	tx, found := e.sent[txHash]
+	if !found {
+		return nil, false, ethereum.NotFound
+	}
+	_, mined := e.receipts[txHash]
+	return tx, !mined, nil
+}
+
+func (e *fakeEtherman) GetTxReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	receipt, found := e.receipts[txHash]
+	if !found {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (e *fakeEtherman) WaitTxToBeMined(ctx context.Context, tx *types.Transaction, timeout time.Duration) (bool, error) {
+	if e.waiting != nil {
+		select {
+		case e.waiting <- struct{}{}:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		select {
+		case <-e.release:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	_, mined := e.receipts[tx.Hash()]
+	return mined, nil
+}
+
+func (e *fakeEtherman) SendTx(ctx context.Context, tx *types.Transaction) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.sent[tx.Hash()] = tx
+	e.sentOrder = append(e.sentOrder, tx.Hash())
+	if e.autoMine {
+		e.receipts[tx.Hash()] = &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash(), BlockNumber: big.NewInt(1)}
+		e.nonce = tx.Nonce() + 1
+	}
+	return nil
+}
+
+func (e *fakeEtherman) CurrentNonce(ctx context.Context, account common.Address) (uint64, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.nonce, nil
+}
+
+func (e *fakeEtherman) SuggestedGasPrice(ctx context.Context) (*big.Int, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return new(big.Int).Set(e.gasPrice), nil
+}
+
+func (e *fakeEtherman) SuggestedGasTipCap(ctx context.Context) (*big.Int, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return new(big.Int).Set(e.gasTipCap), nil
+}
+
+func (e *fakeEtherman) SuggestedBlobGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (e *fakeEtherman) EstimateGas(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) (uint64, error) {
+	return 21000, nil //nolint:gomnd
+}
+
+func (e *fakeEtherman) CheckTxWasMined(ctx context.Context, txHash common.Hash) (bool, *types.Receipt, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	receipt, mined := e.receipts[txHash]
+	return mined, receipt, nil
+}
+
+func (e *fakeEtherman) SignTx(ctx context.Context, sender common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(e.chainID), e.key)
+}
+
+func (e *fakeEtherman) GetRevertMessage(ctx context.Context, tx *types.Transaction) (string, error) {
+	return "", nil
+}
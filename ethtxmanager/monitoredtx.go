@@ -2,11 +2,14 @@ package ethtxmanager
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
 )
 
 // MonitoredTxStatus represents the status of a monitored tx
@@ -33,6 +36,14 @@ const (
 	// status is Successful
 	MonitoredTxStatusConfirmed = MonitoredTxStatus("confirmed")
 
+	// MonitoredTxStatusCanceling means the owner requested to cancel the tx, and a
+	// zero value self transfer with the same nonce was sent to the network
+	MonitoredTxStatusCanceling = MonitoredTxStatus("canceling")
+
+	// MonitoredTxStatusCanceled means the zero value self transfer was mined
+	// instead of any of the txs sent before the cancellation
+	MonitoredTxStatusCanceled = MonitoredTxStatus("canceled")
+
 	// MonitoredTxStatusDone means the tx was set by the owner as done
 	MonitoredTxStatusDone = MonitoredTxStatus("done")
 )
@@ -73,9 +84,23 @@ type monitoredTx struct {
 	// tx gas price
 	gasPrice *big.Int
 
+	// blobSidecar contains the blobs, commitments and proofs carried by an
+	// EIP-4844 blob tx, nil for regular txs
+	blobSidecar *types.BlobTxSidecar
+
+	// blobGasPrice is the max fee per blob gas, only used by blob txs
+	blobGasPrice *big.Int
+
+	// gasTipCap is the max priority fee per gas, only used by blob txs whose gas price is
+	// their max fee per gas
+	gasTipCap *big.Int
+
 	// status of this monitoring
 	status MonitoredTxStatus
 
+	// failureReason describes why the monitored tx was marked as failed
+	failureReason string
+
 	// blockNumber represents the block where the tx was identified
 	// to be mined, it's the same as the block number found in the
 	// tx receipt, this is used to control reorged monitored txs
@@ -95,6 +120,10 @@ type monitoredTx struct {
 
 // Tx uses the current information to build a tx
 func (mTx monitoredTx) Tx() *types.Transaction {
+	if mTx.isBlobTx() {
+		return mTx.blobTx()
+	}
+
 	tx := types.NewTx(&types.LegacyTx{
 		To:       mTx.to,
 		Nonce:    mTx.nonce,
@@ -107,6 +136,103 @@ func (mTx monitoredTx) Tx() *types.Transaction {
 	return tx
 }
 
+// blobTx builds an EIP-4844 blob tx from the current information. The chain id
+// is left empty, it is set by the signer when the tx gets signed.
+func (mTx monitoredTx) blobTx() *types.Transaction {
+	var to common.Address
+	if mTx.to != nil {
+		to = *mTx.to
+	}
+	return types.NewTx(&types.BlobTx{
+		To:         to,
+		Nonce:      mTx.nonce,
+		Value:      uint256.MustFromBig(bigOrZero(mTx.value)),
+		Data:       mTx.data,
+		Gas:        mTx.gas + mTx.gasOffset,
+		GasTipCap:  uint256.MustFromBig(mTx.blobGasTipCap()),
+		GasFeeCap:  uint256.MustFromBig(mTx.gasPrice),
+		BlobFeeCap: uint256.MustFromBig(mTx.blobGasPrice),
+		BlobHashes: mTx.blobSidecar.BlobHashes(),
+		Sidecar:    mTx.blobSidecar,
+	})
+}
+
+// blobGasTipCap returns the max priority fee per gas of the blob tx, the blob txs stored without
+// it are sent with the gas price as before
+func (mTx monitoredTx) blobGasTipCap() *big.Int {
+	if mTx.gasTipCap == nil {
+		return mTx.gasPrice
+	}
+	return mTx.gasTipCap
+}
+
+// isBlobTx returns true if the monitored tx carries blobs
+func (mTx monitoredTx) isBlobTx() bool {
+	return mTx.blobSidecar != nil
+}
+
+// matches returns true if the monitored tx belongs to the owner and has one of the
+// statuses. A nil owner matches all the owners and empty statuses match all the statuses.
+func (mTx monitoredTx) matches(owner *string, statuses []MonitoredTxStatus) bool {
+	if owner != nil && *owner != mTx.owner {
+		return false
+	}
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, status := range statuses {
+		if mTx.status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// isPending checks if the monitored tx is still pending to be monitored
+func (mTx monitoredTx) isPending() bool {
+	return mTx.status == MonitoredTxStatusCreated ||
+		mTx.status == MonitoredTxStatusSent ||
+		mTx.status == MonitoredTxStatusCanceling
+}
+
+// clone returns a copy of the monitored tx not sharing any of its references, so the copies
+// held by the storage and by the monitoring goroutines are changed independently
+func (mTx monitoredTx) clone() monitoredTx {
+	cloned := mTx
+	if mTx.to != nil {
+		to := *mTx.to
+		cloned.to = &to
+	}
+	cloned.value = cloneBig(mTx.value)
+	cloned.data = common.CopyBytes(mTx.data)
+	cloned.gasPrice = cloneBig(mTx.gasPrice)
+	cloned.blobGasPrice = cloneBig(mTx.blobGasPrice)
+	cloned.gasTipCap = cloneBig(mTx.gasTipCap)
+	cloned.blockNumber = cloneBig(mTx.blockNumber)
+	if mTx.blobSidecar != nil {
+		cloned.blobSidecar = &types.BlobTxSidecar{
+			Blobs:       append([]kzg4844.Blob(nil), mTx.blobSidecar.Blobs...),
+			Commitments: append([]kzg4844.Commitment(nil), mTx.blobSidecar.Commitments...),
+			Proofs:      append([]kzg4844.Proof(nil), mTx.blobSidecar.Proofs...),
+		}
+	}
+	if mTx.history != nil {
+		cloned.history = make(map[common.Hash]bool, len(mTx.history))
+		for txHash, sent := range mTx.history {
+			cloned.history[txHash] = sent
+		}
+	}
+	return cloned
+}
+
+// cloneBig returns a copy of the big int, nil if it is nil
+func cloneBig(n *big.Int) *big.Int {
+	if n == nil {
+		return nil
+	}
+	return new(big.Int).Set(n)
+}
+
 // AddHistory adds a transaction to the monitoring history
 func (mTx monitoredTx) AddHistory(tx *types.Transaction) error {
 	if _, found := mTx.history[tx.Hash()]; found {
@@ -174,11 +300,60 @@ func (mTx *monitoredTx) blockNumberU64Ptr() *uint64 {
 	return blockNumber
 }
 
+// bigOrZero returns the provided value or zero if it is nil
+func bigOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+	return v
+}
+
+// blobGasPriceU64Ptr returns the current blobGasPrice as a uint64 pointer
+func (mTx *monitoredTx) blobGasPriceU64Ptr() *uint64 {
+	var blobGasPrice *uint64
+	if mTx.blobGasPrice != nil {
+		tmp := mTx.blobGasPrice.Uint64()
+		blobGasPrice = &tmp
+	}
+	return blobGasPrice
+}
+
+// gasTipCapU64Ptr returns the current gasTipCap as a uint64 pointer
+func (mTx *monitoredTx) gasTipCapU64Ptr() *uint64 {
+	var gasTipCap *uint64
+	if mTx.gasTipCap != nil {
+		tmp := mTx.gasTipCap.Uint64()
+		gasTipCap = &tmp
+	}
+	return gasTipCap
+}
+
+// failureReasonPtr returns the current failureReason field as a string pointer
+func (mTx *monitoredTx) failureReasonPtr() *string {
+	var failureReason *string
+	if mTx.failureReason != "" {
+		tmp := mTx.failureReason
+		failureReason = &tmp
+	}
+	return failureReason
+}
+
+// blobSidecarJSON returns the current blobSidecar field JSON encoded, or nil
+// if the monitored tx does not carry blobs
+func (mTx *monitoredTx) blobSidecarJSON() ([]byte, error) {
+	if mTx.blobSidecar == nil {
+		return nil, nil
+	}
+	return json.Marshal(mTx.blobSidecar)
+}
+
 // MonitoredTxResult represents the result of a execution of a monitored tx
 type MonitoredTxResult struct {
 	ID     string
 	Status MonitoredTxStatus
 	Txs    map[common.Hash]TxResult
+	// FailureReason describes why the monitored tx failed, empty unless the status is failed
+	FailureReason string
 }
 
 // TxResult represents the result of a execution of a ethereum transaction in the block chain
@@ -0,0 +1,96 @@
+package ethtxmanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// nonceManager hands out the nonces of the monitored txs in order for each sender. The
+// nonce reading the chain only accounts for the mined txs, so the monitored txs added
+// before the previous ones get mined would otherwise share the same nonce.
+type nonceManager struct {
+	mutex    sync.Mutex
+	etherman ethermanInterface
+	storage  storageInterface
+	// nonces keeps the next nonce to be handed out for each synced sender
+	nonces map[common.Address]uint64
+}
+
+func newNonceManager(etherman ethermanInterface, storage storageInterface) *nonceManager {
+	return &nonceManager{
+		etherman: etherman,
+		storage:  storage,
+		nonces:   map[common.Address]uint64{},
+	}
+}
+
+// reserve hands out the next nonce of the sender to the add function. The nonce is only
+// consumed if the add function succeeds, so a failure to store the monitored tx does not
+// leave a gap in the sender nonces.
+func (m *nonceManager) reserve(ctx context.Context, from common.Address, add func(nonce uint64) error) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	nonce, err := m.nextNonce(ctx, from)
+	if err != nil {
+		return err
+	}
+	err = add(nonce)
+	if err != nil {
+		return err
+	}
+	m.nonces[from] = nonce + 1
+	return nil
+}
+
+// resync drops the nonce kept for the sender, so the next nonce handed out gets synced
+// again with the chain and the pending monitored txs
+func (m *nonceManager) resync(from common.Address) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.nonces, from)
+}
+
+// nextNonce returns the highest of the nonce kept for the sender and the current nonce
+// of the sender in the chain, in case txs were sent by the sender outside of the eth tx
+// manager. When the sender is not synced yet, the pending monitored txs are considered
+// instead of the kept nonce.
+func (m *nonceManager) nextNonce(ctx context.Context, from common.Address) (uint64, error) {
+	chainNonce, err := m.etherman.CurrentNonce(ctx, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current nonce: %w", err)
+	}
+
+	nonce, synced := m.nonces[from]
+	if !synced {
+		nonce, err = m.pendingNonce(ctx, from)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if chainNonce > nonce {
+		nonce = chainNonce
+	}
+	return nonce, nil
+}
+
+// pendingNonce returns the nonce following the highest nonce of the pending monitored txs
+// of the sender, or zero if the sender has no pending monitored txs
+func (m *nonceManager) pendingNonce(ctx context.Context, from common.Address) (uint64, error) {
+	mTxs, err := m.storage.GetByStatus(ctx, nil, []MonitoredTxStatus{MonitoredTxStatusCreated, MonitoredTxStatusSent, MonitoredTxStatusCanceling})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending monitored txs: %w", err)
+	}
+
+	var nonce uint64
+	for _, mTx := range mTxs {
+		if mTx.from == from && mTx.nonce+1 > nonce {
+			nonce = mTx.nonce + 1
+		}
+	}
+	return nonce, nil
+}
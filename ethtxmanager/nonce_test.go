@@ -0,0 +1,102 @@
+package ethtxmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addTestingTx(t *testing.T, c *Client, etherman *fakeEtherman, id string) uint64 {
+	t.Helper()
+	ctx := context.Background()
+	to := common.HexToAddress("0x2")
+	require.NoError(t, c.Add(ctx, testOwner, id, etherman.address(), &to, nil, nil, 0))
+	mTx, err := c.storage.Get(ctx, testOwner, id)
+	require.NoError(t, err)
+	return mTx.nonce
+}
+
+func TestNonceManagerParallelAdd(t *testing.T) {
+	const txs = 50
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	to := common.HexToAddress("0x2")
+
+	var wg sync.WaitGroup
+	for i := 0; i < txs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, c.Add(ctx, testOwner, fmt.Sprintf("id-%d", i), etherman.address(), &to, nil, nil, 0))
+		}(i)
+	}
+	wg.Wait()
+
+	// Every monitored tx gets its own nonce, without gaps
+	mTxs, err := c.storage.GetByStatus(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, mTxs, txs)
+	nonces := make([]uint64, 0, txs)
+	for _, mTx := range mTxs {
+		nonces = append(nonces, mTx.nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	for i, nonce := range nonces {
+		assert.Equal(t, uint64(i), nonce)
+	}
+}
+
+func TestNonceManagerPendingTxsAfterRestart(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, uint64(i), addTestingTx(t, c, etherman, fmt.Sprintf("id-%d", i)))
+	}
+
+	// A restarted client only knows about the pending monitored txs in the storage
+	c.nonces = newNonceManager(etherman, c.storage)
+	assert.Equal(t, uint64(3), addTestingTx(t, c, etherman, "id-3"))
+}
+
+func TestNonceManagerSyncsWithChain(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	assert.Equal(t, uint64(0), addTestingTx(t, c, etherman, "id-0"))
+
+	// Txs sent by the sender outside of the eth tx manager consumed the nonces
+	etherman.nonce = 10
+	assert.Equal(t, uint64(10), addTestingTx(t, c, etherman, "id-1"))
+	assert.Equal(t, uint64(11), addTestingTx(t, c, etherman, "id-2"))
+}
+
+func TestNonceManagerResyncAfterFailure(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	assert.Equal(t, uint64(0), addTestingTx(t, c, etherman, "id-0"))
+	assert.Equal(t, uint64(1), addTestingTx(t, c, etherman, "id-1"))
+
+	// The last monitored tx fails without consuming its nonce
+	mTx, err := c.storage.Get(ctx, testOwner, "id-1")
+	require.NoError(t, err)
+	mTx.status = MonitoredTxStatusFailed
+	require.NoError(t, c.storage.Update(ctx, mTx))
+	c.nonces.resync(etherman.address())
+
+	assert.Equal(t, uint64(1), addTestingTx(t, c, etherman, "id-2"))
+}
+
+func TestNonceManagerFailedAddDoesNotConsumeNonce(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	assert.Equal(t, uint64(0), addTestingTx(t, c, etherman, "id-0"))
+
+	to := common.HexToAddress("0x2")
+	err := c.Add(ctx, testOwner, "id-0", etherman.address(), &to, nil, nil, 0)
+	require.ErrorIs(t, err, ErrAlreadyExists)
+
+	assert.Equal(t, uint64(1), addTestingTx(t, c, etherman, "id-1"))
+}
@@ -0,0 +1,298 @@
+package ethtxmanager
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PostgresStorage is a durable storage of the monitored txs backed by the
+// zkevm-node state.monitored_txs table. The fields unknown to the zkevm-node
+// are stored in the ethtxmanager.monitored_txs_ext table.
+type PostgresStorage struct {
+	*pgxpool.Pool
+}
+
+// NewPostgresStorage creates a new instance of storage that use
+// postgres to store data
+func NewPostgresStorage(dbCfg db.Config) (*PostgresStorage, error) {
+	db, err := db.NewSQLDB(dbCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresStorage{
+		db,
+	}, nil
+}
+
+// Add persist a monitored tx
+func (s *PostgresStorage) Add(ctx context.Context, mTx monitoredTx) error {
+	blobSidecar, err := mTx.blobSidecarJSON()
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cmd := `
+        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at)
+                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,    $11,       $12,     $13,        $14,        $15)`
+
+	_, err = tx.Exec(ctx, cmd, mTx.owner,
+		mTx.id, mTx.from.String(), mTx.toStringPtr(),
+		mTx.nonce, mTx.valueU64Ptr(), mTx.dataStringPtr(),
+		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), string(mTx.status), mTx.blockNumberU64Ptr(),
+		mTx.historyStringSlice(), timeOrNow(mTx.createdAt), timeOrNow(mTx.updatedAt))
+
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.ConstraintName == "monitored_txs_pkey" {
+			return ErrAlreadyExists
+		} else {
+			return err
+		}
+	}
+
+	err = s.upsertExt(ctx, tx, mTx, blobSidecar)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Get loads the persisted monitored tx matching the owner and id
+func (s *PostgresStorage) Get(ctx context.Context, owner, id string) (monitoredTx, error) {
+	cmd := `
+        SELECT m.owner, m.id, m.from_addr, m.to_addr, m.nonce, m.value, m.data, m.gas, m.gas_offset, m.gas_price, m.status, m.block_num, m.history, m.created_at, m.updated_at, e.blob_sidecar, e.blob_gas_price, e.failure_reason, e.gas_tip_cap
+          FROM state.monitored_txs m
+          LEFT JOIN ethtxmanager.monitored_txs_ext e ON e.owner = m.owner AND e.id = m.id
+         WHERE m.owner = $1
+           AND m.id = $2`
+
+	mTx := monitoredTx{}
+
+	row := s.QueryRow(ctx, cmd, owner, id)
+	err := s.scanMtx(row, &mTx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return mTx, ErrNotFound
+	} else if err != nil {
+		return mTx, err
+	}
+
+	return mTx, nil
+}
+
+// GetByStatus loads all monitored txs of the owner that match the provided statuses,
+// ordered by created at ascending. If the owner is nil, the monitored txs of all the
+// owners are considered. If the statuses are empty, all the statuses are considered.
+func (s *PostgresStorage) GetByStatus(ctx context.Context, owner *string, statuses []MonitoredTxStatus) ([]monitoredTx, error) {
+	hasStatusToFilter := len(statuses) > 0
+
+	cmd := `
+        SELECT m.owner, m.id, m.from_addr, m.to_addr, m.nonce, m.value, m.data, m.gas, m.gas_offset, m.gas_price, m.status, m.block_num, m.history, m.created_at, m.updated_at, e.blob_sidecar, e.blob_gas_price, e.failure_reason, e.gas_tip_cap
+          FROM state.monitored_txs m
+          LEFT JOIN ethtxmanager.monitored_txs_ext e ON e.owner = m.owner AND e.id = m.id
+         WHERE (m.owner = $1 OR $1 IS NULL)`
+	if hasStatusToFilter {
+		cmd += `
+           AND m.status = ANY($2)`
+	}
+	cmd += `
+         ORDER BY m.created_at, m.owner, m.id`
+
+	mTxs := []monitoredTx{}
+
+	var rows pgx.Rows
+	var err error
+	if hasStatusToFilter {
+		rows, err = s.Query(ctx, cmd, owner, statuses)
+	} else {
+		rows, err = s.Query(ctx, cmd, owner)
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return []monitoredTx{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		mTx := monitoredTx{}
+		err := s.scanMtx(rows, &mTx)
+		if err != nil {
+			return nil, err
+		}
+		mTxs = append(mTxs, mTx)
+	}
+
+	return mTxs, rows.Err()
+}
+
+// Update a persisted monitored tx
+func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx) error {
+	blobSidecar, err := mTx.blobSidecarJSON()
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cmd := `
+        UPDATE state.monitored_txs
+           SET from_addr = $3
+             , to_addr = $4
+             , nonce = $5
+             , value = $6
+             , data = $7
+             , gas = $8
+             , gas_offset = $9
+             , gas_price = $10
+             , status = $11
+             , block_num = $12
+             , history = $13
+             , updated_at = $14
+         WHERE owner = $1
+           AND id = $2`
+
+	tag, err := tx.Exec(ctx, cmd, mTx.owner,
+		mTx.id, mTx.from.String(), mTx.toStringPtr(),
+		mTx.nonce, mTx.valueU64Ptr(), mTx.dataStringPtr(),
+		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), string(mTx.status), mTx.blockNumberU64Ptr(),
+		mTx.historyStringSlice(), time.Now().UTC().Round(time.Microsecond))
+
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	err = s.upsertExt(ctx, tx, mTx, blobSidecar)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// upsertExt persists the fields of the monitored tx unknown to the zkevm-node, which
+// are missing for the monitored txs added by the zkevm-node
+func (s *PostgresStorage) upsertExt(ctx context.Context, tx pgx.Tx, mTx monitoredTx, blobSidecar []byte) error {
+	cmd := `
+        INSERT INTO ethtxmanager.monitored_txs_ext (owner, id, blob_sidecar, blob_gas_price, gas_tip_cap, failure_reason)
+                                            VALUES (   $1, $2,           $3,             $4,          $5,             $6)
+        ON CONFLICT (owner, id) DO UPDATE
+           SET blob_sidecar = EXCLUDED.blob_sidecar
+             , blob_gas_price = EXCLUDED.blob_gas_price
+             , gas_tip_cap = EXCLUDED.gas_tip_cap
+             , failure_reason = EXCLUDED.failure_reason`
+
+	_, err := tx.Exec(ctx, cmd, mTx.owner, mTx.id,
+		blobSidecar, mTx.blobGasPriceU64Ptr(), mTx.gasTipCapU64Ptr(), mTx.failureReasonPtr())
+	return err
+}
+
+// Close closes all the connections of the pool
+func (s *PostgresStorage) Close() error {
+	s.Pool.Close()
+	return nil
+}
+
+// scanMtx scans a row and fill the provided instance of monitoredTx with
+// the row data
+func (s *PostgresStorage) scanMtx(row pgx.Row, mTx *monitoredTx) error {
+	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, status, history, created_at, updated_at, blob_sidecar, blob_gas_price, failure_reason, gas_tip_cap
+	var from, status string
+	var to, data, failureReason *string
+	var history []string
+	var value, blockNumber, blobGasPrice, gasTipCap *uint64
+	var gasPrice uint64
+	var blobSidecar []byte
+
+	err := row.Scan(&mTx.owner, &mTx.id, &from, &to, &mTx.nonce, &value,
+		&data, &mTx.gas, &mTx.gasOffset, &gasPrice, &status, &blockNumber, &history,
+		&mTx.createdAt, &mTx.updatedAt, &blobSidecar, &blobGasPrice, &failureReason, &gasTipCap)
+	if err != nil {
+		return err
+	}
+
+	mTx.from = common.HexToAddress(from)
+	mTx.gasPrice = big.NewInt(0).SetUint64(gasPrice)
+	mTx.status = MonitoredTxStatus(status)
+
+	if to != nil {
+		tmp := common.HexToAddress(*to)
+		mTx.to = &tmp
+	}
+	if value != nil {
+		tmp := *value
+		mTx.value = big.NewInt(0).SetUint64(tmp)
+	}
+	if data != nil {
+		tmp := *data
+		bytes, err := hex.DecodeString(tmp)
+		if err != nil {
+			return err
+		}
+		mTx.data = bytes
+	}
+	if blockNumber != nil {
+		tmp := *blockNumber
+		mTx.blockNumber = big.NewInt(0).SetUint64(tmp)
+	}
+	if blobSidecar != nil {
+		mTx.blobSidecar = &types.BlobTxSidecar{}
+		err := json.Unmarshal(blobSidecar, mTx.blobSidecar)
+		if err != nil {
+			return err
+		}
+	}
+	if blobGasPrice != nil {
+		tmp := *blobGasPrice
+		mTx.blobGasPrice = big.NewInt(0).SetUint64(tmp)
+	}
+	if failureReason != nil {
+		mTx.failureReason = *failureReason
+	}
+	if gasTipCap != nil {
+		tmp := *gasTipCap
+		mTx.gasTipCap = big.NewInt(0).SetUint64(tmp)
+	}
+
+	h := make(map[common.Hash]bool, len(history))
+	for _, txHash := range history {
+		h[common.HexToHash(txHash)] = true
+	}
+	mTx.history = h
+
+	return nil
+}
+
+// timeOrNow returns the provided time rounded to the postgres precision,
+// or the current time if it is not set
+func timeOrNow(t time.Time) time.Time {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Round(time.Microsecond)
+}
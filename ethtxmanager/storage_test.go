@@ -0,0 +1,217 @@
+package ethtxmanager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storageFactories creates a fresh instance of every storage implementation, so the
+// same contracts are checked against all of them
+var storageFactories = map[string]func(t *testing.T) storageInterface{
+	"memory": func(t *testing.T) storageInterface {
+		return NewMonitoredTxsStorage()
+	},
+	"leveldb": func(t *testing.T) storageInterface {
+		storage, err := NewLevelDBStorage(t.TempDir())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = storage.Close() })
+		return storage
+	},
+	"postgres": func(t *testing.T) storageInterface {
+		return newTestingPostgresStorage(t)
+	},
+}
+
+func newTestingMonitoredTx(owner, id string, status MonitoredTxStatus, createdAt time.Time) monitoredTx {
+	return monitoredTx{
+		owner: owner, id: id, from: common.HexToAddress("0x1"),
+		value: big.NewInt(0), gasPrice: big.NewInt(1), status: status,
+		history:   map[common.Hash]bool{},
+		createdAt: createdAt.UTC().Round(time.Microsecond),
+		updatedAt: createdAt.UTC().Round(time.Microsecond),
+	}
+}
+
+func TestStorageGetDoesNotFallbackToOtherTxsOfOwner(t *testing.T) {
+	for name, newStorage := range storageFactories {
+		t.Run(name, func(t *testing.T) {
+			storage := newStorage(t)
+			ctx := context.Background()
+
+			require.NoError(t, storage.Add(ctx, newTestingMonitoredTx("owner", "id1", MonitoredTxStatusCreated, time.Now())))
+
+			_, err := storage.Get(ctx, "owner", "id2")
+			assert.ErrorIs(t, err, ErrNotFound)
+			_, err = storage.Get(ctx, "other", "id1")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			mTx, err := storage.Get(ctx, "owner", "id1")
+			require.NoError(t, err)
+			assert.Equal(t, "owner", mTx.owner)
+			assert.Equal(t, "id1", mTx.id)
+		})
+	}
+}
+
+func TestStorageSameIDDifferentOwners(t *testing.T) {
+	for name, newStorage := range storageFactories {
+		t.Run(name, func(t *testing.T) {
+			storage := newStorage(t)
+			ctx := context.Background()
+
+			require.NoError(t, storage.Add(ctx, newTestingMonitoredTx("owner1", "id", MonitoredTxStatusCreated, time.Now())))
+			require.NoError(t, storage.Add(ctx, newTestingMonitoredTx("owner2", "id", MonitoredTxStatusSent, time.Now())))
+
+			mTx1, err := storage.Get(ctx, "owner1", "id")
+			require.NoError(t, err)
+			assert.Equal(t, MonitoredTxStatusCreated, mTx1.status)
+
+			mTx2, err := storage.Get(ctx, "owner2", "id")
+			require.NoError(t, err)
+			assert.Equal(t, MonitoredTxStatusSent, mTx2.status)
+
+			// Updating the tx of an owner leaves the tx of the other owner untouched
+			mTx1.status = MonitoredTxStatusConfirmed
+			require.NoError(t, storage.Update(ctx, mTx1))
+			mTx2, err = storage.Get(ctx, "owner2", "id")
+			require.NoError(t, err)
+			assert.Equal(t, MonitoredTxStatusSent, mTx2.status)
+		})
+	}
+}
+
+func TestStorageAddDuplicated(t *testing.T) {
+	for name, newStorage := range storageFactories {
+		t.Run(name, func(t *testing.T) {
+			storage := newStorage(t)
+			ctx := context.Background()
+
+			mTx := newTestingMonitoredTx("owner", "id", MonitoredTxStatusCreated, time.Now())
+			require.NoError(t, storage.Add(ctx, mTx))
+
+			duplicated := mTx
+			duplicated.status = MonitoredTxStatusSent
+			assert.ErrorIs(t, storage.Add(ctx, duplicated), ErrAlreadyExists)
+
+			// The stored tx is not overwritten by the duplicated one
+			stored, err := storage.Get(ctx, "owner", "id")
+			require.NoError(t, err)
+			assert.Equal(t, MonitoredTxStatusCreated, stored.status)
+		})
+	}
+}
+
+func TestStorageUpdateNotFound(t *testing.T) {
+	for name, newStorage := range storageFactories {
+		t.Run(name, func(t *testing.T) {
+			storage := newStorage(t)
+			mTx := newTestingMonitoredTx("owner", "id", MonitoredTxStatusCreated, time.Now())
+			assert.ErrorIs(t, storage.Update(context.Background(), mTx), ErrNotFound)
+		})
+	}
+}
+
+func TestStorageUpdateRefreshesUpdatedAt(t *testing.T) {
+	for name, newStorage := range storageFactories {
+		t.Run(name, func(t *testing.T) {
+			storage := newStorage(t)
+			ctx := context.Background()
+			createdAt := time.Now().Add(-time.Hour)
+
+			mTx := newTestingMonitoredTx("owner", "id", MonitoredTxStatusCreated, createdAt)
+			require.NoError(t, storage.Add(ctx, mTx))
+			mTx.status = MonitoredTxStatusSent
+			require.NoError(t, storage.Update(ctx, mTx))
+
+			updated, err := storage.Get(ctx, "owner", "id")
+			require.NoError(t, err)
+			assert.True(t, mTx.createdAt.Equal(updated.createdAt))
+			assert.True(t, updated.updatedAt.After(mTx.updatedAt))
+		})
+	}
+}
+
+func TestStorageGetByStatusOrderAndFilters(t *testing.T) {
+	for name, newStorage := range storageFactories {
+		t.Run(name, func(t *testing.T) {
+			storage := newStorage(t)
+			ctx := context.Background()
+
+			now := time.Now()
+			mTxs := []monitoredTx{
+				newTestingMonitoredTx("owner", "3", MonitoredTxStatusSent, now.Add(3*time.Second)),
+				newTestingMonitoredTx("owner", "1", MonitoredTxStatusCreated, now.Add(1*time.Second)),
+				newTestingMonitoredTx("other", "0", MonitoredTxStatusCreated, now),
+				newTestingMonitoredTx("owner", "4", MonitoredTxStatusConfirmed, now.Add(4*time.Second)),
+				newTestingMonitoredTx("owner", "2", MonitoredTxStatusCreated, now.Add(2*time.Second)),
+			}
+			for _, mTx := range mTxs {
+				require.NoError(t, storage.Add(ctx, mTx))
+			}
+
+			ids := func(mTxs []monitoredTx) []string {
+				ids := []string{}
+				for _, mTx := range mTxs {
+					ids = append(ids, mTx.owner+"/"+mTx.id)
+				}
+				return ids
+			}
+
+			owner := "owner"
+			result, err := storage.GetByStatus(ctx, &owner, []MonitoredTxStatus{MonitoredTxStatusCreated, MonitoredTxStatusSent})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"owner/1", "owner/2", "owner/3"}, ids(result))
+
+			result, err = storage.GetByStatus(ctx, nil, []MonitoredTxStatus{MonitoredTxStatusCreated})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"other/0", "owner/1", "owner/2"}, ids(result))
+
+			// Empty statuses match all the statuses
+			result, err = storage.GetByStatus(ctx, &owner, nil)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"owner/1", "owner/2", "owner/3", "owner/4"}, ids(result))
+
+			// Owners sharing a prefix are not mixed
+			prefix := "own"
+			result, err = storage.GetByStatus(ctx, &prefix, nil)
+			require.NoError(t, err)
+			assert.Empty(t, result)
+		})
+	}
+}
+
+func TestStorageDoesNotShareMonitoredTxs(t *testing.T) {
+	for name, newStorage := range storageFactories {
+		t.Run(name, func(t *testing.T) {
+			storage := newStorage(t)
+			ctx := context.Background()
+
+			mTx := newTestingMonitoredTx("owner", "id", MonitoredTxStatusCreated, time.Now())
+			require.NoError(t, storage.Add(ctx, mTx))
+			mTx.history[common.HexToHash("0x1")] = true
+
+			// Changing a loaded tx does not change the stored one until it is updated
+			loaded, err := storage.Get(ctx, "owner", "id")
+			require.NoError(t, err)
+			assert.Empty(t, loaded.history)
+			loaded.history[common.HexToHash("0x2")] = true
+			loaded.gasPrice.SetUint64(2)
+			stored, err := storage.Get(ctx, "owner", "id")
+			require.NoError(t, err)
+			assert.Empty(t, stored.history)
+			assert.Equal(t, big.NewInt(1), stored.gasPrice)
+
+			require.NoError(t, storage.Update(ctx, loaded))
+			loaded.history[common.HexToHash("0x3")] = true
+			stored, err = storage.Get(ctx, "owner", "id")
+			require.NoError(t, err)
+			assert.Len(t, stored.history, 1)
+		})
+	}
+}
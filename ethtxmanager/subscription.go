@@ -0,0 +1,134 @@
+package ethtxmanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// subscriptionBufferSize is the number of results a subscription can hold before
+// it falls behind and gets closed
+const subscriptionBufferSize = 128
+
+// Subscription receives the results of the monitored txs of the subscribed owners
+// every time their status changes, or they are replaced
+type Subscription struct {
+	id      uint64
+	owners  map[string]bool
+	results chan MonitoredTxResult
+	subs    *subscriptions
+}
+
+// Results returns the channel where the results are pushed. The channel is closed when
+// the subscription is unsubscribed, or when it falls behind and the results can not be
+// pushed anymore, in which case the subscriber needs to subscribe again and load the
+// current results.
+func (s *Subscription) Results() <-chan MonitoredTxResult {
+	return s.results
+}
+
+// Unsubscribe stops pushing results to the subscription and closes its channel
+func (s *Subscription) Unsubscribe() {
+	s.subs.remove(s.id)
+}
+
+// matches checks if the subscription is interested in the monitored txs of the owner,
+// a subscription without owners is interested in all of them
+func (s *Subscription) matches(owner string) bool {
+	return len(s.owners) == 0 || s.owners[owner]
+}
+
+// subscriptions keeps the active subscriptions to the monitored tx results
+type subscriptions struct {
+	mutex  sync.Mutex
+	nextID uint64
+	subs   map[uint64]*Subscription
+}
+
+func (s *subscriptions) add(owners []string) *Subscription {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.subs == nil {
+		s.subs = map[uint64]*Subscription{}
+	}
+	sub := &Subscription{
+		id:      s.nextID,
+		owners:  make(map[string]bool, len(owners)),
+		results: make(chan MonitoredTxResult, subscriptionBufferSize),
+		subs:    s,
+	}
+	for _, owner := range owners {
+		sub.owners[owner] = true
+	}
+	s.subs[sub.id] = sub
+	s.nextID++
+	return sub
+}
+
+func (s *subscriptions) remove(id uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sub, found := s.subs[id]
+	if !found {
+		return
+	}
+	delete(s.subs, id)
+	close(sub.results)
+}
+
+// interested checks if any subscription is interested in the monitored txs of the owner
+func (s *subscriptions) interested(owner string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, sub := range s.subs {
+		if sub.matches(owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// publish pushes the result to the interested subscriptions without blocking, the
+// subscriptions that fell behind are closed
+func (s *subscriptions) publish(owner string, result MonitoredTxResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id, sub := range s.subs {
+		if !sub.matches(owner) {
+			continue
+		}
+		select {
+		case sub.results <- result:
+		default:
+			fmt.Printf("subscription %v fell behind, closing it\n", id)
+			delete(s.subs, id)
+			close(sub.results)
+		}
+	}
+}
+
+// Subscribe creates a subscription receiving the results of the monitored txs of the
+// provided owners every time their status changes, or they are replaced. If no owners are
+// provided, the results of all the monitored txs are received.
+func (c *Client) Subscribe(owners ...string) *Subscription {
+	return c.subscriptions.add(owners)
+}
+
+// publishResult pushes the result of the monitored tx to the subscriptions after its
+// status changed. The result is only built if someone is interested in it, and the
+// status change is published without the txs details if they can not be loaded.
+func (c *Client) publishResult(ctx context.Context, mTx monitoredTx) {
+	if !c.subscriptions.interested(mTx.owner) {
+		return
+	}
+	result, err := c.buildResult(ctx, mTx)
+	if err != nil {
+		fmt.Printf("failed to build result of monitored tx %v to publish it: %v\n", mTx.id, err)
+		result = MonitoredTxResult{ID: mTx.id, Status: mTx.status, FailureReason: mTx.failureReason}
+	}
+	c.subscriptions.publish(mTx.owner, result)
+}
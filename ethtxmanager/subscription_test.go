@@ -0,0 +1,128 @@
+package ethtxmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveResult(t *testing.T, sub *Subscription) MonitoredTxResult {
+	t.Helper()
+	select {
+	case result, ok := <-sub.Results():
+		require.True(t, ok, "subscription closed")
+		return result
+	case <-time.After(time.Second):
+		require.FailNow(t, "no result received")
+		return MonitoredTxResult{}
+	}
+}
+
+func TestSubscribeFiltersByOwner(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	etherman.autoMine = true
+	ctx := context.Background()
+
+	ownerSub := c.Subscribe(testOwner)
+	defer ownerSub.Unsubscribe()
+	otherSub := c.Subscribe("other")
+	defer otherSub.Unsubscribe()
+	allSub := c.Subscribe()
+	defer allSub.Unsubscribe()
+
+	to := common.HexToAddress("0x2")
+	require.NoError(t, c.Add(ctx, testOwner, "id", etherman.address(), &to, nil, nil, 0))
+	require.NoError(t, c.monitorTxs(ctx))
+
+	for _, sub := range []*Subscription{ownerSub, allSub} {
+		result := receiveResult(t, sub)
+		assert.Equal(t, "id", result.ID)
+		assert.Equal(t, MonitoredTxStatusSent, result.Status)
+		result = receiveResult(t, sub)
+		assert.Equal(t, MonitoredTxStatusConfirmed, result.Status)
+		assert.Len(t, result.Txs, 1)
+	}
+	assert.Empty(t, otherSub.Results())
+}
+
+func TestSubscriptionClosedWhenFallingBehind(t *testing.T) {
+	c, _ := newTestingClient(t, Config{})
+	sub := c.Subscribe(testOwner)
+
+	for i := 0; i < subscriptionBufferSize+1; i++ {
+		c.subscriptions.publish(testOwner, MonitoredTxResult{ID: "id"})
+	}
+
+	received := 0
+	for range sub.Results() {
+		received++
+	}
+	assert.Equal(t, subscriptionBufferSize, received)
+
+	// Unsubscribing a closed subscription is a no-op
+	sub.Unsubscribe()
+}
+
+func TestProcessPendingMonitoredTxsWaitsForResults(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	sendTestingTx(t, c, etherman, "id", nil)
+
+	results := make(chan MonitoredTxResult, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.ProcessPendingMonitoredTxs(ctx, testOwner, func(result MonitoredTxResult) {
+			results <- result
+		})
+	}()
+
+	// Let the processing subscribe and find the sent monitored tx before it gets mined
+	require.Eventually(t, func() bool { return c.subscriptions.interested(testOwner) }, time.Second, time.Millisecond)
+	etherman.mine(etherman.lastSent())
+	require.NoError(t, c.monitorTxs(ctx))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "pending monitored txs still processing")
+	}
+	result := <-results
+	assert.Equal(t, "id", result.ID)
+	assert.Equal(t, MonitoredTxStatusConfirmed, result.Status)
+
+	status, err := c.Result(ctx, testOwner, "id")
+	require.NoError(t, err)
+	assert.Equal(t, MonitoredTxStatusDone, status.Status)
+}
+
+func TestProcessMonitoredTxsDoesNotWait(t *testing.T) {
+	c, etherman := newTestingClient(t, Config{})
+	ctx := context.Background()
+	sendTestingTx(t, c, etherman, "first", nil)
+	etherman.mine(etherman.lastSent())
+	sendTestingTx(t, c, etherman, "second", nil)
+
+	var results []MonitoredTxResult
+	pending, err := c.ProcessMonitoredTxs(ctx, testOwner, func(result MonitoredTxResult) {
+		results = append(results, result)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending)
+	require.Len(t, results, 1)
+	assert.Equal(t, "first", results[0].ID)
+	assert.Equal(t, MonitoredTxStatusConfirmed, results[0].Status)
+
+	// The handled results are not processed again
+	results = nil
+	pending, err = c.ProcessMonitoredTxs(ctx, testOwner, func(result MonitoredTxResult) {
+		results = append(results, result)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending)
+	assert.Empty(t, results)
+}
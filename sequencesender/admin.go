@@ -0,0 +1,133 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/sieniven/zkevm-nubit/log"
+)
+
+const (
+	// AdminAPINamespace is the JSON-RPC namespace of the admin API methods
+	AdminAPINamespace = "admin"
+	// adminServerShutdownTimeout is the time given to the admin API server to finish the
+	// requests being served when shutting down
+	adminServerShutdownTimeout = 5 * time.Second
+	// adminServerReadHeaderTimeout is the time allowed to read the request headers
+	adminServerReadHeaderTimeout = 10 * time.Second
+)
+
+// SenderStatus is the status of the sequence sender returned by the admin API
+type SenderStatus struct {
+	Paused            bool           `json:"paused"`
+	SendTriggered     bool           `json:"sendTriggered"`
+	NextBatchNumber   hexutil.Uint64 `json:"nextBatchNumber"`
+	MaxBatchesForL1   hexutil.Uint64 `json:"maxBatchesForL1"`
+	MaxBatchBytesSize hexutil.Uint64 `json:"maxBatchBytesSize"`
+}
+
+// AdminAPI is the JSON-RPC API controlling the sequence sender, its methods are served
+// under the admin namespace, e.g. admin_sendSequence
+type AdminAPI struct {
+	sender *SequenceSender
+}
+
+// NewAdminAPI creates the admin API of the sequence sender
+func NewAdminAPI(sender *SequenceSender) *AdminAPI {
+	return &AdminAPI{sender: sender}
+}
+
+// SendSequence triggers sending a sequence, which is delayed until resumed if paused
+func (api *AdminAPI) SendSequence() {
+	api.sender.TriggerSend()
+}
+
+// Pause stops sending sequences
+func (api *AdminAPI) Pause() {
+	api.sender.Pause()
+}
+
+// Resume sends sequences again after being paused
+func (api *AdminAPI) Resume() {
+	api.sender.Resume()
+}
+
+// SetBatchLimits sets the number of batches of the sequences and the size of the batches
+func (api *AdminAPI) SetBatchLimits(maxBatchesForL1, maxBatchBytesSize hexutil.Uint64) error {
+	return api.sender.SetBatchLimits(uint64(maxBatchesForL1), uint64(maxBatchBytesSize))
+}
+
+// Status returns the status of the sequence sender
+func (api *AdminAPI) Status() SenderStatus {
+	maxBatchesForL1, maxBatchBytesSize := api.sender.BatchLimits()
+	return SenderStatus{
+		Paused:            api.sender.IsPaused(),
+		SendTriggered:     api.sender.IsSendTriggered(),
+		NextBatchNumber:   hexutil.Uint64(api.sender.NextBatchNumber()),
+		MaxBatchesForL1:   hexutil.Uint64(maxBatchesForL1),
+		MaxBatchBytesSize: hexutil.Uint64(maxBatchBytesSize),
+	}
+}
+
+// NextBatchNumber returns the number of the next batch to be sequenced
+func (api *AdminAPI) NextBatchNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.sender.NextBatchNumber())
+}
+
+// PendingMonitoredTxs returns the results of the sequences txs not confirmed yet on L1
+func (api *AdminAPI) PendingMonitoredTxs(ctx context.Context) ([]ethtxmanager.MonitoredTxResult, error) {
+	return api.sender.PendingMonitoredTxs(ctx)
+}
+
+// SubmissionHistory returns the latest submissions of sequences to the data availability layer
+func (api *AdminAPI) SubmissionHistory() []DASubmission {
+	return api.sender.DAHistory()
+}
+
+// StartAdminServer serves the admin API over HTTP until the context is done. It returns
+// once the server is listening, or if it fails to listen.
+func (s *SequenceSender) StartAdminServer(ctx context.Context) (net.Addr, error) {
+	server := rpc.NewServer()
+	err := server.RegisterName(AdminAPINamespace, NewAdminAPI(s))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register admin API: %w", err)
+	}
+
+	address := net.JoinHostPort(s.cfg.Admin.Host, strconv.Itoa(s.cfg.Admin.Port))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		server.Stop()
+		return nil, fmt.Errorf("failed to listen on %v: %w", address, err)
+	}
+
+	httpServer := &http.Server{
+		Handler:           server,
+		ReadHeaderTimeout: adminServerReadHeaderTimeout,
+	}
+	go func() {
+		log.Infof("admin API listening on %v", listener.Addr())
+		err := httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("admin API server failed: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), adminServerShutdownTimeout)
+		defer cancel()
+		err := httpServer.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Errorf("failed to shut down admin API server: %v", err)
+		}
+		server.Stop()
+	}()
+	return listener.Addr(), nil
+}
@@ -0,0 +1,79 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestingAdminClient(t *testing.T, s *SequenceSender) *rpc.Client {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	addr, err := s.StartAdminServer(ctx)
+	require.NoError(t, err)
+
+	client, err := rpc.DialHTTP("http://" + addr.String())
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestAdminAPI(t *testing.T) {
+	etm, err := ethtxmanager.New(ethtxmanager.Config{}, nil)
+	require.NoError(t, err)
+	s, err := New(Config{MaxBatchesForL1: 10, MaxBatchBytesSize: 100, Admin: AdminConfig{Host: "127.0.0.1"}}, nil, etm)
+	require.NoError(t, err)
+	client := newTestingAdminClient(t, s)
+
+	var status SenderStatus
+	require.NoError(t, client.Call(&status, "admin_status"))
+	assert.Equal(t, SenderStatus{MaxBatchesForL1: 10, MaxBatchBytesSize: 100}, status)
+
+	require.NoError(t, client.Call(nil, "admin_pause"))
+	require.NoError(t, client.Call(nil, "admin_sendSequence"))
+	require.NoError(t, client.Call(nil, "admin_setBatchLimits", hexutil.Uint64(2), hexutil.Uint64(50)))
+	require.NoError(t, client.Call(&status, "admin_status"))
+	assert.Equal(t, SenderStatus{Paused: true, SendTriggered: true, MaxBatchesForL1: 2, MaxBatchBytesSize: 50}, status)
+
+	// A paused sequence sender does not send the triggered sequence
+	require.NoError(t, s.tryToSendSequence(context.Background()))
+	assert.True(t, s.IsSendTriggered())
+
+	require.NoError(t, client.Call(nil, "admin_resume"))
+	assert.False(t, s.IsPaused())
+
+	err = client.Call(nil, "admin_setBatchLimits", hexutil.Uint64(0), hexutil.Uint64(50))
+	var rpcErr rpc.Error
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, ErrInvalidBatchLimits.Error(), rpcErr.Error())
+
+	var nextBatchNumber hexutil.Uint64
+	require.NoError(t, client.Call(&nextBatchNumber, "admin_nextBatchNumber"))
+	assert.Equal(t, hexutil.Uint64(0), nextBatchNumber)
+
+	var pending []ethtxmanager.MonitoredTxResult
+	require.NoError(t, client.Call(&pending, "admin_pendingMonitoredTxs"))
+	assert.Empty(t, pending)
+}
+
+func TestDASubmissionHistory(t *testing.T) {
+	s, err := New(Config{Admin: AdminConfig{Host: "127.0.0.1"}}, nil, nil)
+	require.NoError(t, err)
+	for i := uint64(0); i < daHistorySize+5; i++ {
+		s.recordDASubmission(DASubmission{FromBatch: i, ToBatch: i})
+	}
+	client := newTestingAdminClient(t, s)
+
+	var history []DASubmission
+	require.NoError(t, client.Call(&history, "admin_submissionHistory"))
+	require.Len(t, history, daHistorySize)
+	assert.Equal(t, uint64(5), history[0].FromBatch)
+	assert.Equal(t, uint64(daHistorySize+4), history[daHistorySize-1].ToBatch)
+}
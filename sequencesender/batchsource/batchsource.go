@@ -0,0 +1,81 @@
+package batchsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sieniven/zkevm-nubit/config/types"
+	batchTypes "github.com/sieniven/zkevm-nubit/sequencesender/types"
+)
+
+// Type is the kind of source the batches to sequence are read from
+type Type string
+
+const (
+	// TypeSynthetic generates batches filled with mock data
+	TypeSynthetic Type = "synthetic"
+	// TypeRPC pulls the closed batches from a zkevm-node JSON-RPC
+	TypeRPC Type = "rpc"
+	// TypeFile reads the batches L2 data from a JSONL file or a directory
+	TypeFile Type = "file"
+)
+
+// DefaultTimeout is the timeout of the requests to the zkevm-node JSON-RPC when not configured
+const DefaultTimeout = 10 * time.Second
+
+// ErrBatchNotAvailable when the batch is not closed yet, or is not in the source yet
+var ErrBatchNotAvailable = errors.New("batch not available")
+
+// BatchSource provides the batches to be sequenced
+type BatchSource interface {
+	// GetBatch returns the closed batch with the number, or ErrBatchNotAvailable if the
+	// batch can not be sequenced yet
+	GetBatch(ctx context.Context, number uint64) (*batchTypes.Batch, error)
+}
+
+// Config is the configuration of the batch source of the sequence sender
+type Config struct {
+	// Type is the kind of batch source, synthetic by default
+	Type Type `mapstructure:"Type"`
+
+	// URL is the zkevm-node JSON-RPC URL of the rpc batch source
+	URL string `mapstructure:"URL"`
+
+	// Timeout is the timeout of the requests to the zkevm-node JSON-RPC
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// Path is the JSONL file or the directory the file batch source reads from. Each line of
+	// a JSONL file is a batch in the zkevm_getBatchByNumber format, and each file of a directory
+	// holds the hex encoded L2 data of a batch. The batches are sequenced in the order they
+	// are read, files of a directory being sorted by name.
+	Path string `mapstructure:"Path"`
+}
+
+// New creates the configured batch source. The file source hands out its batches from the
+// first batch number on, and the synthetic source generates batches for the coinbase with the
+// size returned by the batchBytesSize function.
+func New(cfg Config, firstBatchNumber uint64, coinbase common.Address, batchBytesSize func() uint64) (BatchSource, error) {
+	switch cfg.Type {
+	case TypeSynthetic, "":
+		return NewSyntheticSource(coinbase, batchBytesSize), nil
+	case TypeRPC:
+		if cfg.URL == "" {
+			return nil, errors.New("rpc batch source requires a URL")
+		}
+		timeout := cfg.Timeout.Duration
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		return NewRPCSource(cfg.URL, timeout)
+	case TypeFile:
+		if cfg.Path == "" {
+			return nil, errors.New("file batch source requires a path")
+		}
+		return NewFileSource(cfg.Path, firstBatchNumber)
+	default:
+		return nil, fmt.Errorf("unsupported batch source type: %s", cfg.Type)
+	}
+}
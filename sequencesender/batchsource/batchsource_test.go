@@ -0,0 +1,160 @@
+package batchsource
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	configTypes "github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticSource(t *testing.T) {
+	size := uint64(5)
+	coinbase := common.HexToAddress("0xc0")
+	s, err := New(Config{}, 0, coinbase, func() uint64 { return size })
+	require.NoError(t, err)
+
+	batch, err := s.GetBatch(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), batch.BatchNumber)
+	assert.Equal(t, coinbase, batch.Coinbase)
+	assert.Equal(t, []byte{10, 10, 10, 10, 10}, batch.BatchL2Data)
+
+	size = 2
+	batch, err = s.GetBatch(context.Background(), 8)
+	require.NoError(t, err)
+	assert.Len(t, batch.BatchL2Data, 2)
+}
+
+// stubZKEVMAPI serves the batches of a zkevm-node
+type stubZKEVMAPI struct {
+	batches map[uint64]*types.Batch
+}
+
+func (api *stubZKEVMAPI) GetBatchByNumber(number hexutil.Uint64, fullTx bool) (*types.Batch, error) {
+	return api.batches[uint64(number)], nil
+}
+
+func TestRPCSource(t *testing.T) {
+	api := &stubZKEVMAPI{batches: map[uint64]*types.Batch{
+		1: {Number: 1, Coinbase: common.HexToAddress("0xc0"), Timestamp: 100, Closed: true, BatchL2Data: []byte{1, 2}},
+		2: {Number: 2, Closed: false, BatchL2Data: []byte{3}},
+	}}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("zkevm", api))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	s, err := New(Config{Type: TypeRPC, URL: httpServer.URL, Timeout: configTypes.NewDuration(time.Second)}, 0, common.Address{}, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	batch, err := s.GetBatch(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), batch.BatchNumber)
+	assert.Equal(t, common.HexToAddress("0xc0"), batch.Coinbase)
+	assert.Equal(t, []byte{1, 2}, batch.BatchL2Data)
+	assert.Equal(t, int64(100), batch.Timestamp.Unix())
+
+	// Open batches and unknown batches are not available yet
+	_, err = s.GetBatch(ctx, 2)
+	require.ErrorIs(t, err, ErrBatchNotAvailable)
+	_, err = s.GetBatch(ctx, 3)
+	require.ErrorIs(t, err, ErrBatchNotAvailable)
+}
+
+func TestFileSourceJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batches.jsonl")
+	content := `{"batchL2Data":"0x0102","timestamp":"0x64"}` + "\n\n" + `{"batchL2Data":"0x03"}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600)) //nolint:gomnd
+
+	s, err := New(Config{Type: TypeFile, Path: path}, 5, common.Address{}, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	batch, err := s.GetBatch(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), batch.BatchNumber)
+	assert.Equal(t, []byte{1, 2}, batch.BatchL2Data)
+	assert.Equal(t, int64(100), batch.Timestamp.Unix())
+	batch, err = s.GetBatch(ctx, 6)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3}, batch.BatchL2Data)
+	assert.False(t, batch.Timestamp.IsZero())
+
+	_, err = s.GetBatch(ctx, 7)
+	require.ErrorIs(t, err, ErrBatchNotAvailable)
+	_, err = s.GetBatch(ctx, 4)
+	require.Error(t, err)
+
+	// The batches appended are picked up once their line is complete
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600) //nolint:gomnd
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.WriteString(`{"batchL2Data":"0x04`)
+	require.NoError(t, err)
+	_, err = s.GetBatch(ctx, 7)
+	require.ErrorIs(t, err, ErrBatchNotAvailable)
+	_, err = file.WriteString(`05"}`)
+	require.NoError(t, err)
+	batch, err = s.GetBatch(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{4, 5}, batch.BatchL2Data)
+}
+
+func TestFileSourceDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)) //nolint:gomnd
+	}
+	write("002", "0x0304\n")
+	write("001", "0102")
+	write(".hidden", "zz")
+
+	s, err := New(Config{Type: TypeFile, Path: dir}, 1, common.Address{}, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	batch, err := s.GetBatch(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, batch.BatchL2Data)
+	batch, err = s.GetBatch(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3, 4}, batch.BatchL2Data)
+	_, err = s.GetBatch(ctx, 3)
+	require.ErrorIs(t, err, ErrBatchNotAvailable)
+
+	write("003", "05")
+	batch, err = s.GetBatch(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{5}, batch.BatchL2Data)
+
+	// A file sorting before the last one read would be read out of order
+	write("0025", "06")
+	_, err = s.GetBatch(ctx, 4)
+	require.ErrorContains(t, err, "sorts before the last batch file read 003")
+	require.NoError(t, os.Remove(filepath.Join(dir, "0025")))
+
+	write("004", "not hex")
+	_, err = s.GetBatch(ctx, 4)
+	require.Error(t, err)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for _, cfg := range []Config{{Type: "kafka"}, {Type: TypeRPC}, {Type: TypeFile}, {Type: TypeFile, Path: "/nonexistent"}} {
+		_, err := New(cfg, 0, common.Address{}, nil)
+		assert.Error(t, err)
+	}
+}
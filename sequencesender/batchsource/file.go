@@ -0,0 +1,185 @@
+package batchsource
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	batchTypes "github.com/sieniven/zkevm-nubit/sequencesender/types"
+)
+
+// FileSource reads the batches from a JSONL file or a directory. The source is read again
+// when a batch is not found, so batches appended to the file or added to the directory
+// are picked up as a feed.
+type FileSource struct {
+	mutex            sync.Mutex
+	path             string
+	isDir            bool
+	firstBatchNumber uint64
+	batches          []batchTypes.Batch
+
+	// offset is the position of the JSONL file read so far
+	offset int64
+	// files are the names of the directory files read so far, and lastFile the last one in
+	// name order
+	files    map[string]bool
+	lastFile string
+}
+
+// NewFileSource creates a source reading the batches from the JSONL file or the directory of
+// the path, handing out the batches read in order from the first batch number on
+func NewFileSource(path string, firstBatchNumber uint64) (*FileSource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch source %s: %w", path, err)
+	}
+	s := &FileSource{
+		path:             path,
+		isDir:            info.IsDir(),
+		firstBatchNumber: firstBatchNumber,
+		files:            map[string]bool{},
+	}
+	err = s.read()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetBatch returns the batch read at the position of the number, or ErrBatchNotAvailable if
+// not enough batches were read yet
+func (s *FileSource) GetBatch(ctx context.Context, number uint64) (*batchTypes.Batch, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if number < s.firstBatchNumber {
+		return nil, fmt.Errorf("batch %d is before the first batch %d of the source", number, s.firstBatchNumber)
+	}
+	index := number - s.firstBatchNumber
+	if index >= uint64(len(s.batches)) {
+		err := s.read()
+		if err != nil {
+			return nil, err
+		}
+		if index >= uint64(len(s.batches)) {
+			return nil, ErrBatchNotAvailable
+		}
+	}
+
+	batch := s.batches[index]
+	batch.BatchNumber = number
+	return &batch, nil
+}
+
+// read reads the batches added to the source since the last read
+func (s *FileSource) read() error {
+	if s.isDir {
+		return s.readDir()
+	}
+	return s.readJSONL()
+}
+
+// readJSONL reads the lines appended to the JSONL file. A last line without line break is only
+// read if complete, as it may still be being written.
+func (s *FileSource) readJSONL() error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to open batch source %s: %w", s.path, err)
+	}
+	defer file.Close()
+	_, err = file.Seek(s.offset, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("failed to read batch source %s: %w", s.path, err)
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read batch source %s: %w", s.path, err)
+		}
+		eof := errors.Is(err, io.EOF)
+		if len(bytes.TrimSpace(line)) > 0 {
+			var batch types.Batch
+			decodeErr := json.Unmarshal(line, &batch)
+			if decodeErr != nil {
+				if eof {
+					// the last line is not complete yet
+					return nil
+				}
+				return fmt.Errorf("failed to decode batch at offset %d of batch source %s: %w", s.offset, s.path, decodeErr)
+			}
+			s.batches = append(s.batches, fromFileBatch(batch))
+		}
+		s.offset += int64(len(line))
+		if eof {
+			return nil
+		}
+	}
+}
+
+// readDir reads the files added to the directory, in name order. The batches are numbered by
+// the order they are read, so a file added with a name sorting before the last file read is
+// rejected instead of being read out of order.
+func (s *FileSource) readDir() error {
+	entries, err := os.ReadDir(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read batch source %s: %w", s.path, err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || s.files[entry.Name()] {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	if len(names) > 0 && names[0] < s.lastFile {
+		return fmt.Errorf("batch file %s of batch source %s sorts before the last batch file read %s", names[0], s.path, s.lastFile)
+	}
+
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(s.path, name))
+		if err != nil {
+			return fmt.Errorf("failed to read batch file %s: %w", name, err)
+		}
+		hexData := strings.TrimSpace(string(content))
+		if !strings.HasPrefix(hexData, "0x") {
+			hexData = "0x" + hexData
+		}
+		data, err := hexutil.Decode(hexData)
+		if err != nil {
+			return fmt.Errorf("failed to decode batch file %s: %w", name, err)
+		}
+		s.batches = append(s.batches, batchTypes.Batch{
+			BatchL2Data: data,
+			Timestamp:   time.Now(),
+		})
+		s.files[name] = true
+		s.lastFile = name
+	}
+	return nil
+}
+
+// fromFileBatch converts the batch read from a JSONL file, which is stamped with the current
+// time if it has no timestamp
+func fromFileBatch(batch types.Batch) batchTypes.Batch {
+	b := fromRPCBatch(batch)
+	if batch.Timestamp == 0 {
+		b.Timestamp = time.Now()
+	}
+	return *b
+}
@@ -0,0 +1,65 @@
+package batchsource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	batchTypes "github.com/sieniven/zkevm-nubit/sequencesender/types"
+)
+
+// RPCSource pulls the closed batches from a zkevm-node JSON-RPC
+type RPCSource struct {
+	client  *rpc.Client
+	timeout time.Duration
+}
+
+// NewRPCSource creates a source pulling the batches from the zkevm-node JSON-RPC URL
+func NewRPCSource(url string, timeout time.Duration) (*RPCSource, error) {
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to zkevm-node JSON-RPC %s: %w", url, err)
+	}
+	return &RPCSource{
+		client:  client,
+		timeout: timeout,
+	}, nil
+}
+
+// GetBatch gets the batch with the number from the zkevm-node, it is only available once closed
+func (s *RPCSource) GetBatch(ctx context.Context, number uint64) (*batchTypes.Batch, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var batch *types.Batch
+	err := s.client.CallContext(ctx, &batch, "zkevm_getBatchByNumber", hexutil.Uint64(number), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch %d: %w", number, err)
+	}
+	if batch == nil || !batch.Closed {
+		return nil, ErrBatchNotAvailable
+	}
+	return fromRPCBatch(*batch), nil
+}
+
+// fromRPCBatch converts the batch in the zkevm-node JSON-RPC format
+func fromRPCBatch(batch types.Batch) *batchTypes.Batch {
+	b := &batchTypes.Batch{
+		BatchNumber:    uint64(batch.Number),
+		Coinbase:       batch.Coinbase,
+		BatchL2Data:    batch.BatchL2Data,
+		StateRoot:      batch.StateRoot,
+		LocalExitRoot:  batch.LocalExitRoot,
+		AccInputHash:   batch.AccInputHash,
+		Timestamp:      time.Unix(int64(batch.Timestamp), 0),
+		GlobalExitRoot: batch.GlobalExitRoot,
+	}
+	if batch.ForcedBatchNumber != nil {
+		forcedBatchNumber := uint64(*batch.ForcedBatchNumber)
+		b.ForcedBatchNum = &forcedBatchNumber
+	}
+	return b
+}
@@ -0,0 +1,38 @@
+package batchsource
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	batchTypes "github.com/sieniven/zkevm-nubit/sequencesender/types"
+)
+
+// syntheticDataByte is the byte the synthetic batches L2 data is filled with
+const syntheticDataByte = byte(10)
+
+// SyntheticSource generates batches filled with mock data, every batch is available
+type SyntheticSource struct {
+	coinbase       common.Address
+	batchBytesSize func() uint64
+}
+
+// NewSyntheticSource creates a source generating batches for the coinbase, with the L2 data
+// size returned by the batchBytesSize function when each batch is generated
+func NewSyntheticSource(coinbase common.Address, batchBytesSize func() uint64) *SyntheticSource {
+	return &SyntheticSource{
+		coinbase:       coinbase,
+		batchBytesSize: batchBytesSize,
+	}
+}
+
+// GetBatch generates the batch with the number
+func (s *SyntheticSource) GetBatch(ctx context.Context, number uint64) (*batchTypes.Batch, error) {
+	return &batchTypes.Batch{
+		BatchNumber: number,
+		Coinbase:    s.coinbase,
+		BatchL2Data: bytes.Repeat([]byte{syntheticDataByte}, int(s.batchBytesSize())),
+		Timestamp:   time.Now(),
+	}, nil
+}
@@ -3,6 +3,8 @@ package sequencesender
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/sieniven/zkevm-nubit/sequencesender/batchsource"
+	"github.com/sieniven/zkevm-nubit/signer"
 )
 
 type Config struct {
@@ -13,7 +15,9 @@ type Config struct {
 	// MaxTxSizeForL1 is the maximum size a single transaction can have. This field has
 	// non-trivial consequences: larger transactions than 128KB are significantly harder and
 	// more expensive to propagate; larger transactions also take more resources
-	// to validate whether they fit into the pool or not.
+	// to validate whether they fit into the pool or not. The batches of a sequence are
+	// limited so the estimated size of its L1 tx does not exceed it, a zero value disables
+	// the limit.
 	MaxTxSizeForL1 uint64 `mapstructure:"MaxTxSizeForL1"`
 
 	// SenderAddress defines which private key the eth tx manager needs to use
@@ -35,12 +39,107 @@ type Config struct {
 	// final gas: 1100
 	GasOffset uint64 `mapstructure:"GasOffset"`
 
+	// MaxPendingSequences is the maximum number of sequences in flight, sent to L1 and not
+	// confirmed yet. The next sequence is posted to the DA layer while they wait for their L1
+	// confirmation. With 1, a sequence is only posted once the previous one is confirmed.
+	// The gas of a sequence sent while others are in flight can not be estimated on the latest
+	// L1 state, so EthTxManager.ForcedGas needs to be set to send more than one.
+	MaxPendingSequences uint64 `mapstructure:"MaxPendingSequences"`
+
+	// DAMessageMaxAge is the age up to which the data availability message of a sequence is
+	// reused to send it again when its L1 tx fails. Older messages are posted again to the DA
+	// layer, as their proof may have expired. Zero always posts them again.
+	DAMessageMaxAge types.Duration `mapstructure:"DAMessageMaxAge"`
+
 	// MaxBatchesForL1 is the maximum amount of batches to be sequenced in a single L1 tx
 	MaxBatchesForL1 uint64 `mapstructure:"MaxBatchesForL1"`
 
-	// Mock config to replicate the BatchConstraintsCfg in the zkevm node.
+	// Mock config to replicate the BatchConstraintsCfg in the zkevm node. It is the size of
+	// the batches generated by the synthetic batch source.
 	MaxBatchBytesSize uint64 `mapstructure:"MaxBatchBytesSize"`
 
+	// FirstBatchNumber is the number of the first batch sequenced
+	FirstBatchNumber uint64 `mapstructure:"FirstBatchNumber"`
+
+	// LastBatchStoragePath is the file persisting the last sequenced batch, so the batch numbering
+	// resumes after a restart. An empty path disables it.
+	LastBatchStoragePath string `mapstructure:"LastBatchStoragePath"`
+
+	// ReconcileWithL1 resumes after the last batch sequenced on L1 on startup, unless the
+	// monitored tx of the persisted last sequenced batch is still pending
+	ReconcileWithL1 bool `mapstructure:"ReconcileWithL1"`
+
+	// L1SyncFromBlock is the L1 block the SequenceBatches events are searched from when there
+	// is no persisted last sequenced batch mined on L1, e.g. the rollup deployment block
+	L1SyncFromBlock uint64 `mapstructure:"L1SyncFromBlock"`
+
+	// BatchSource is the source of the batches to sequence
+	BatchSource batchsource.Config `mapstructure:"BatchSource"`
+
 	// DA Permit API private key
 	DAPermitApiPrivateKey types.KeystoreFileConfig `mapstructure:"DAPermitApiPrivateKey"`
+
+	// DASigner is the signer of the data availability messages. The keystore signer uses
+	// the DAPermitApiPrivateKey keystore file
+	DASigner signer.Config `mapstructure:"DASigner"`
+
+	// StdinHandlerEnabled enables triggering the sending of a sequence by typing 's' in
+	// the standard input of the node
+	StdinHandlerEnabled bool `mapstructure:"StdinHandlerEnabled"`
+
+	// Admin is the configuration of the admin API controlling the sequence sender
+	Admin AdminConfig `mapstructure:"Admin"`
+
+	// Triggers are the policies closing and sending a sequence without a manual trigger
+	Triggers TriggersConfig `mapstructure:"Triggers"`
+
+	// ForcedBatches is the configuration of the batches forced on L1
+	ForcedBatches ForcedBatchesConfig `mapstructure:"ForcedBatches"`
+}
+
+// ForcedBatchesConfig is the configuration of the forced batches watched on L1 and sequenced by
+// the sequence sender
+type ForcedBatchesConfig struct {
+	// Enabled watches the ForceBatch events of the rollup from L1SyncFromBlock on, so the forced
+	// batches are sequenced with the data committed on L1
+	Enabled bool `mapstructure:"Enabled"`
+
+	// PollInterval is the time between the queries of the ForceBatch events
+	PollInterval types.Duration `mapstructure:"PollInterval"`
+
+	// Inject mixes the forced batches into the batches of the source, in the order they were
+	// forced. It is only allowed with the synthetic batch source, the rpc and file sources report
+	// the forced batches included by the L2 node instead.
+	Inject bool `mapstructure:"Inject"`
+}
+
+// TriggersConfig is the configuration of the policies closing and sending a sequence of the
+// pending batches, each policy is disabled when set to zero
+type TriggersConfig struct {
+	// MinPendingBatches sends a sequence when at least this number of batches is pending
+	MinPendingBatches uint64 `mapstructure:"MinPendingBatches"`
+
+	// MinPendingBytes sends a sequence when the L2 data of the pending batches reaches this size
+	MinPendingBytes uint64 `mapstructure:"MinPendingBytes"`
+
+	// MaxPendingBatchAge sends a sequence when the oldest pending batch is older than this.
+	// This policy is urgent, so it is not postponed by MaxL1GasPrice.
+	MaxPendingBatchAge types.Duration `mapstructure:"MaxPendingBatchAge"`
+
+	// MaxL1GasPrice postpones the sequences sent by the MinPendingBatches and MinPendingBytes
+	// policies while the L1 gas price in wei is above it
+	MaxL1GasPrice uint64 `mapstructure:"MaxL1GasPrice"`
+}
+
+// AdminConfig is the configuration of the admin JSON-RPC API of the sequence sender
+type AdminConfig struct {
+	// Enabled starts the admin API server
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Host is the address the admin API server listens on. It is meant to be reachable
+	// only locally, as the API is not authenticated
+	Host string `mapstructure:"Host"`
+
+	// Port is the port the admin API server listens on
+	Port int `mapstructure:"Port"`
 }
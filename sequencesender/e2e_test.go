@@ -0,0 +1,210 @@
+package sequencesender
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sieniven/zkevm-nubit/config/types"
+	polygonzkevm "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonvalidium_xlayer"
+	ethmanTypes "github.com/sieniven/zkevm-nubit/etherman/types"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/sieniven/zkevm-nubit/sequencesender/batchsource"
+	"github.com/sieniven/zkevm-nubit/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDA returns a DA message made of the number of the batches posted
+type fakeDA struct {
+	mutex  sync.Mutex
+	posted [][]uint64
+}
+
+func (da *fakeDA) PostSequence(ctx context.Context, sequences []ethmanTypes.Sequence) ([]byte, error) {
+	da.mutex.Lock()
+	defer da.mutex.Unlock()
+	var batches []uint64
+	message := []byte{}
+	for _, sequence := range sequences {
+		batches = append(batches, sequence.BatchNumber)
+		message = append(message, byte(sequence.BatchNumber))
+	}
+	da.posted = append(da.posted, batches)
+	return message, nil
+}
+
+func (da *fakeDA) postedBatches() [][]uint64 {
+	da.mutex.Lock()
+	defer da.mutex.Unlock()
+	return append([][]uint64{}, da.posted...)
+}
+
+func TestSequenceSentToSimulatedL1(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l1 := testutil.NewSimulatedL1(t, testutil.Config{DeployDAProtocol: testutil.DeployStub})
+	sender := crypto.PubkeyToAddress(l1.Admin.PublicKey)
+	client := l1.Backend
+
+	etm, err := ethtxmanager.New(ethtxmanager.Config{
+		FrequenceToMonitorTxs: types.NewDuration(50 * time.Millisecond),
+		WaitTxToBeMined:       types.NewDuration(5 * time.Second),
+		GasPriceMarginFactor:  1,
+		StorageType:           ethtxmanager.StorageTypeMemory,
+	}, l1.Etherman)
+	require.NoError(t, err)
+
+	// Batch 1 is sequenced by the validium initialization, the batches are timestamped before
+	// the simulated L1 blocks
+	var lines []string
+	for i := 0; i < 3; i++ {
+		lines = append(lines, fmt.Sprintf(`{"batchL2Data":"0x%s","timestamp":"0x1"}`, strings.Repeat("ab", 10*(i+1))))
+	}
+	path := filepath.Join(t.TempDir(), "batches.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600)) //nolint:gomnd
+
+	s, err := New(Config{
+		WaitPeriodSendSequence: types.NewDuration(50 * time.Millisecond),
+		SenderAddress:          sender,
+		L2Coinbase:             sender,
+		MaxPendingSequences:    1,
+		MaxBatchesForL1:        3,
+		MaxBatchBytesSize:      100,
+		FirstBatchNumber:       2,
+		BatchSource:            batchsource.Config{Type: batchsource.TypeFile, Path: path},
+	}, l1.Etherman, etm)
+	require.NoError(t, err)
+	da := &fakeDA{}
+	s.SetDataProvider(da)
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+
+	l1.Mine(t, 50*time.Millisecond) //nolint:gomnd
+	var wg sync.WaitGroup
+	wg.Add(2) //nolint:gomnd
+	go func() {
+		defer wg.Done()
+		etm.Start(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Start(ctx))
+	}()
+	defer wg.Wait()
+	defer cancel()
+
+	s.TriggerSend()
+	require.Eventually(t, func() bool {
+		result, err := etm.Result(ctx, ethTxManagerOwner, "sequence-from-2-to-4")
+		return err == nil && result.Status == ethtxmanager.MonitoredTxStatusDone
+	}, 20*time.Second, 50*time.Millisecond) //nolint:gomnd
+
+	assert.Equal(t, [][]uint64{{2, 3, 4}}, da.postedBatches())
+	event, err := l1.Etherman.GetLastSequencedBatch(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), event.BatchNumber)
+	receipt, err := client.TransactionReceipt(ctx, event.TxHash)
+	require.NoError(t, err)
+	assert.Equal(t, ethTypes.ReceiptStatusSuccessful, receipt.Status)
+
+	// The sequence tx carries the DA message of the posted batches
+	tx, _, err := client.TransactionByHash(ctx, event.TxHash)
+	require.NoError(t, err)
+	validiumABI, err := polygonzkevm.PolygonvalidiumXlayerMetaData.GetAbi()
+	require.NoError(t, err)
+	args, err := validiumABI.Methods["sequenceBatchesValidium"].Inputs.Unpack(tx.Data()[4:])
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2, 3, 4}, args[len(args)-1])
+}
+
+func TestForcedBatchSequencedToSimulatedL1(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l1 := testutil.NewSimulatedL1(t, testutil.Config{DeployDAProtocol: testutil.DeployStub})
+	sender := crypto.PubkeyToAddress(l1.Admin.PublicKey)
+
+	// The admin is the force batch address of the validium
+	fee, err := l1.Etherman.GetForcedBatchFee()
+	require.NoError(t, err)
+	forcedTransactions := []byte{0x0b, 0x0f}
+	_, err = l1.Etherman.ForceBatch(sender, forcedTransactions, fee)
+	require.NoError(t, err)
+	l1.Backend.Commit()
+
+	etm, err := ethtxmanager.New(ethtxmanager.Config{
+		FrequenceToMonitorTxs: types.NewDuration(50 * time.Millisecond),
+		WaitTxToBeMined:       types.NewDuration(5 * time.Second),
+		GasPriceMarginFactor:  1,
+		StorageType:           ethtxmanager.StorageTypeMemory,
+	}, l1.Etherman)
+	require.NoError(t, err)
+
+	// The sequence only holds the injected forced batch, timestamped by the simulated L1
+	cfg := Config{
+		WaitPeriodSendSequence: types.NewDuration(50 * time.Millisecond),
+		SenderAddress:          sender,
+		L2Coinbase:             sender,
+		MaxPendingSequences:    1,
+		MaxBatchesForL1:        1,
+		MaxBatchBytesSize:      10,
+		FirstBatchNumber:       2,
+		BatchSource:            batchsource.Config{Type: batchsource.TypeSynthetic},
+		ForcedBatches: ForcedBatchesConfig{
+			Enabled:      true,
+			PollInterval: types.NewDuration(50 * time.Millisecond),
+			Inject:       true,
+		},
+	}
+	s, err := New(cfg, l1.Etherman, etm)
+	require.NoError(t, err)
+	da := &fakeDA{}
+	s.SetDataProvider(da)
+	watcher := NewForcedBatchWatcher(cfg.ForcedBatches, l1.Etherman, 0)
+	require.NoError(t, watcher.sync(ctx))
+	s.SetForcedBatchWatcher(watcher)
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+
+	l1.Mine(t, 50*time.Millisecond) //nolint:gomnd
+	var wg sync.WaitGroup
+	wg.Add(3) //nolint:gomnd
+	go func() {
+		defer wg.Done()
+		etm.Start(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		watcher.Start(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Start(ctx))
+	}()
+	defer wg.Wait()
+	defer cancel()
+
+	s.TriggerSend()
+	require.Eventually(t, func() bool {
+		result, err := etm.Result(ctx, ethTxManagerOwner, "sequence-from-2-to-2")
+		return err == nil && result.Status == ethtxmanager.MonitoredTxStatusDone
+	}, 20*time.Second, 50*time.Millisecond) //nolint:gomnd
+
+	// The forced batch is not posted to the DA layer
+	assert.Empty(t, da.postedBatches())
+	lastForceBatchSequenced, err := l1.Etherman.GetLastForceBatchSequenced()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastForceBatchSequenced)
+	event, err := l1.Etherman.GetLastSequencedBatch(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), event.BatchNumber)
+	require.Eventually(t, func() bool {
+		_, ok := watcher.ForcedBatch(1)
+		return !ok
+	}, 5*time.Second, 50*time.Millisecond) //nolint:gomnd
+}
@@ -0,0 +1,179 @@
+package sequencesender
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ethmanTypes "github.com/sieniven/zkevm-nubit/etherman/types"
+	"github.com/sieniven/zkevm-nubit/log"
+	batchTypes "github.com/sieniven/zkevm-nubit/sequencesender/types"
+)
+
+// defaultForcedBatchesPollInterval is the time between the queries of the ForceBatch events when
+// not configured
+const defaultForcedBatchesPollInterval = 5 * time.Second
+
+// errForcedBatchNotRead when the forced batch of a batch is not read from L1 yet
+var errForcedBatchNotRead = errors.New("forced batch not read from L1")
+
+// l1ForcedBatchReader reads the batches forced on L1
+type l1ForcedBatchReader interface {
+	GetForcedBatches(ctx context.Context, fromBlock uint64) ([]ethmanTypes.ForcedBatch, uint64, error)
+	GetLastForceBatchSequenced() (uint64, error)
+}
+
+// ForcedBatchWatcher follows the batches forced on L1 that are not sequenced yet, so the
+// sequence sender sequences them with the data committed by the rollup
+type ForcedBatchWatcher struct {
+	reader       l1ForcedBatchReader
+	pollInterval time.Duration
+
+	mutex sync.Mutex
+	// nextBlock is the next L1 block to scan for ForceBatch events
+	nextBlock uint64
+	// synced is set once the forced batches were read up to the latest L1 block
+	synced bool
+	// lastSequenced is the last forced batch sequenced on L1
+	lastSequenced uint64
+	// forced are the forced batches not sequenced yet, by forced batch number
+	forced map[uint64]ethmanTypes.ForcedBatch
+}
+
+// NewForcedBatchWatcher creates a watcher reading the forced batches from the L1 block on
+func NewForcedBatchWatcher(cfg ForcedBatchesConfig, reader l1ForcedBatchReader, fromBlock uint64) *ForcedBatchWatcher {
+	pollInterval := cfg.PollInterval.Duration
+	if pollInterval == 0 {
+		pollInterval = defaultForcedBatchesPollInterval
+	}
+	return &ForcedBatchWatcher{
+		reader:       reader,
+		pollInterval: pollInterval,
+		nextBlock:    fromBlock,
+		forced:       map[uint64]ethmanTypes.ForcedBatch{},
+	}
+}
+
+// Start reads the forced batches every poll interval until the context is done
+func (w *ForcedBatchWatcher) Start(ctx context.Context) {
+	for ctx.Err() == nil {
+		err := w.sync(ctx)
+		if err != nil {
+			log.Errorf("error reading the forced batches: %v", err)
+		}
+		sleep(ctx, w.pollInterval)
+	}
+	log.Info("forced batch watcher stopped")
+}
+
+// sync reads the forced batches forced since the last sync, and drops the ones sequenced on L1
+func (w *ForcedBatchWatcher) sync(ctx context.Context) error {
+	w.mutex.Lock()
+	fromBlock := w.nextBlock
+	w.mutex.Unlock()
+
+	// the last sequenced forced batch is read first, so no forced batch read after it is dropped
+	// before it is sequenced
+	lastSequenced, err := w.reader.GetLastForceBatchSequenced()
+	if err != nil {
+		return fmt.Errorf("failed to get the last forced batch sequenced: %w", err)
+	}
+	forcedBatches, lastBlock, err := w.reader.GetForcedBatches(ctx, fromBlock)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, forcedBatch := range forcedBatches {
+		if forcedBatch.ForcedBatchNumber > lastSequenced {
+			log.Infof("batch %d forced in L1 tx %v of block %d", forcedBatch.ForcedBatchNumber, forcedBatch.TxHash, forcedBatch.BlockNumber)
+			w.forced[forcedBatch.ForcedBatchNumber] = forcedBatch
+		}
+	}
+	for number := range w.forced {
+		if number <= lastSequenced {
+			delete(w.forced, number)
+		}
+	}
+	w.lastSequenced = lastSequenced
+	w.nextBlock = lastBlock + 1
+	w.synced = true
+	return nil
+}
+
+// ForcedBatch returns the forced batch with the number, if it was read from L1 and is not
+// sequenced yet
+func (w *ForcedBatchWatcher) ForcedBatch(number uint64) (ethmanTypes.ForcedBatch, bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	forcedBatch, ok := w.forced[number]
+	return forcedBatch, ok
+}
+
+// LastSequenced returns the last forced batch sequenced on L1 as of the last sync, and whether
+// the forced batches were synced yet
+func (w *ForcedBatchWatcher) LastSequenced() (uint64, bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.lastSequenced, w.synced
+}
+
+// getForcedBatch returns the forced batch of the batch, whose L2 data must be the forced
+// transactions, or errForcedBatchNotRead if the watcher did not read it yet
+func (s *SequenceSender) getForcedBatch(batch *batchTypes.Batch) (ethmanTypes.ForcedBatch, error) {
+	if s.forcedBatches == nil {
+		return ethmanTypes.ForcedBatch{}, fmt.Errorf("batch %d is forced batch %d, but the forced batches are not watched", batch.BatchNumber, *batch.ForcedBatchNum)
+	}
+	forcedBatch, ok := s.forcedBatches.ForcedBatch(*batch.ForcedBatchNum)
+	if !ok {
+		return ethmanTypes.ForcedBatch{}, errForcedBatchNotRead
+	}
+	if !bytes.Equal(batch.BatchL2Data, forcedBatch.Transactions) {
+		return ethmanTypes.ForcedBatch{}, fmt.Errorf("L2 data of batch %d does not match the transactions of forced batch %d", batch.BatchNumber, forcedBatch.ForcedBatchNumber)
+	}
+	return forcedBatch, nil
+}
+
+// nextForcedBatchToInject returns the next forced batch as the batch with the number, if forced
+// batches are injected and one is waiting to be sequenced
+func (s *SequenceSender) nextForcedBatchToInject(batchNumber uint64) *batchTypes.Batch {
+	if s.forcedBatches == nil || !s.cfg.ForcedBatches.Inject {
+		return nil
+	}
+	if s.nextForcedBatch == 0 {
+		lastSequenced, synced := s.forcedBatches.LastSequenced()
+		if !synced {
+			return nil
+		}
+		s.nextForcedBatch = lastSequenced + 1
+	}
+	forcedBatch, ok := s.forcedBatches.ForcedBatch(s.nextForcedBatch)
+	if !ok {
+		return nil
+	}
+	s.nextForcedBatch++
+	forcedBatchNumber := forcedBatch.ForcedBatchNumber
+	return &batchTypes.Batch{
+		BatchNumber:    batchNumber,
+		Coinbase:       s.cfg.L2Coinbase,
+		BatchL2Data:    forcedBatch.Transactions,
+		Timestamp:      forcedBatch.ForcedAt,
+		GlobalExitRoot: forcedBatch.GlobalExitRoot,
+		ForcedBatchNum: &forcedBatchNumber,
+	}
+}
+
+// onlyForcedBatches checks if the sequences are all forced batches, which are not posted to the
+// DA layer
+func onlyForcedBatches(sequences []ethmanTypes.Sequence) bool {
+	for _, seq := range sequences {
+		if seq.ForcedBatchTimestamp == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package sequencesender
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethmanTypes "github.com/sieniven/zkevm-nubit/etherman/types"
+	"github.com/sieniven/zkevm-nubit/sequencesender/batchsource"
+	batchTypes "github.com/sieniven/zkevm-nubit/sequencesender/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeForcedBatchReader returns the forced batches of the blocks up to the latest block
+type fakeForcedBatchReader struct {
+	forced        []ethmanTypes.ForcedBatch
+	latestBlock   uint64
+	lastSequenced uint64
+}
+
+func (r *fakeForcedBatchReader) GetForcedBatches(ctx context.Context, fromBlock uint64) ([]ethmanTypes.ForcedBatch, uint64, error) {
+	var forcedBatches []ethmanTypes.ForcedBatch
+	for _, forcedBatch := range r.forced {
+		if forcedBatch.BlockNumber >= fromBlock && forcedBatch.BlockNumber <= r.latestBlock {
+			forcedBatches = append(forcedBatches, forcedBatch)
+		}
+	}
+	return forcedBatches, r.latestBlock, nil
+}
+
+func (r *fakeForcedBatchReader) GetLastForceBatchSequenced() (uint64, error) {
+	return r.lastSequenced, nil
+}
+
+// newTestingForcedBatch creates the forced batch with the number, forced in the block
+func newTestingForcedBatch(number, blockNumber uint64) ethmanTypes.ForcedBatch {
+	return ethmanTypes.ForcedBatch{
+		ForcedBatchNumber: number,
+		GlobalExitRoot:    common.BigToHash(new(big.Int).SetUint64(number)),
+		ForcedAt:          time.Unix(int64(1000+number), 0),
+		PrevBlockHash:     common.BigToHash(new(big.Int).SetUint64(blockNumber - 1)),
+		Transactions:      []byte{0x0f, byte(number)},
+		BlockNumber:       blockNumber,
+	}
+}
+
+func TestForcedBatchWatcherSync(t *testing.T) {
+	ctx := context.Background()
+	reader := &fakeForcedBatchReader{
+		forced:      []ethmanTypes.ForcedBatch{newTestingForcedBatch(1, 10), newTestingForcedBatch(2, 20)},
+		latestBlock: 15,
+	}
+	w := NewForcedBatchWatcher(ForcedBatchesConfig{}, reader, 0)
+	_, synced := w.LastSequenced()
+	assert.False(t, synced)
+
+	require.NoError(t, w.sync(ctx))
+	forcedBatch, ok := w.ForcedBatch(1)
+	require.True(t, ok)
+	assert.Equal(t, reader.forced[0], forcedBatch)
+	_, ok = w.ForcedBatch(2)
+	assert.False(t, ok)
+	lastSequenced, synced := w.LastSequenced()
+	assert.True(t, synced)
+	assert.Zero(t, lastSequenced)
+
+	// The forced batches sequenced on L1 are dropped
+	reader.latestBlock = 25
+	reader.lastSequenced = 1
+	require.NoError(t, w.sync(ctx))
+	_, ok = w.ForcedBatch(1)
+	assert.False(t, ok)
+	_, ok = w.ForcedBatch(2)
+	assert.True(t, ok)
+	lastSequenced, _ = w.LastSequenced()
+	assert.Equal(t, uint64(1), lastSequenced)
+	assert.Equal(t, uint64(26), w.nextBlock)
+}
+
+func TestGetSequencesToSendInjectsForcedBatches(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(Config{
+		MaxBatchesForL1:   4,
+		MaxBatchBytesSize: 2,
+		FirstBatchNumber:  2,
+		ForcedBatches:     ForcedBatchesConfig{Enabled: true, Inject: true},
+		BatchSource:       batchsource.Config{Type: batchsource.TypeSynthetic},
+	}, nil, nil)
+	require.NoError(t, err)
+	reader := &fakeForcedBatchReader{
+		forced:        []ethmanTypes.ForcedBatch{newTestingForcedBatch(1, 10), newTestingForcedBatch(2, 10), newTestingForcedBatch(3, 10)},
+		latestBlock:   10,
+		lastSequenced: 1,
+	}
+	w := NewForcedBatchWatcher(ForcedBatchesConfig{}, reader, 0)
+	s.SetForcedBatchWatcher(w)
+
+	// The forced batches are not injected until read from L1
+	sequences, err := s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 4)
+	for _, seq := range sequences {
+		assert.Zero(t, seq.ForcedBatchTimestamp)
+	}
+
+	// The forced batches not sequenced yet are sequenced first, in order
+	require.NoError(t, w.sync(ctx))
+	sequences, err = s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 4)
+	for i, number := range []uint64{2, 3} {
+		forcedBatch := reader.forced[number-1]
+		assert.Equal(t, uint64(6+i), sequences[i].BatchNumber)
+		assert.Equal(t, forcedBatch.Transactions, sequences[i].BatchL2Data)
+		assert.Equal(t, forcedBatch.GlobalExitRoot, sequences[i].GlobalExitRoot)
+		assert.Equal(t, forcedBatch.ForcedAt.Unix(), sequences[i].ForcedBatchTimestamp)
+		assert.Equal(t, forcedBatch.PrevBlockHash, sequences[i].PrevBlockHash)
+	}
+	assert.Zero(t, sequences[2].ForcedBatchTimestamp)
+	assert.False(t, onlyForcedBatches(sequences))
+	assert.True(t, onlyForcedBatches(sequences[:2]))
+
+	// Every forced batch is only injected once
+	sequences, err = s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	for _, seq := range sequences {
+		assert.Zero(t, seq.ForcedBatchTimestamp)
+	}
+}
+
+// forcedBatchSource returns batches with a forced batch number, as reported by the L2 node
+type forcedBatchSource struct {
+	batches map[uint64]*batchTypes.Batch
+}
+
+func (s *forcedBatchSource) GetBatch(ctx context.Context, number uint64) (*batchTypes.Batch, error) {
+	batch, ok := s.batches[number]
+	if !ok {
+		return nil, batchsource.ErrBatchNotAvailable
+	}
+	return batch, nil
+}
+
+func TestForcedBatchesOnlyInjectedIntoSyntheticSource(t *testing.T) {
+	cfg := Config{
+		ForcedBatches: ForcedBatchesConfig{Enabled: true, Inject: true},
+		BatchSource:   batchsource.Config{Type: batchsource.TypeRPC, URL: "http://localhost:8123"},
+	}
+	_, err := New(cfg, nil, nil)
+	assert.ErrorIs(t, err, ErrForcedBatchesInjectedIntoSource)
+
+	// The forced batches are read from the source instead
+	cfg.ForcedBatches.Inject = false
+	_, err = New(cfg, nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestGetSequencesToSendWithForcedBatchesOfSource(t *testing.T) {
+	ctx := context.Background()
+	forcedBatch := newTestingForcedBatch(1, 10)
+	forcedBatchNumber := uint64(1)
+	source := &forcedBatchSource{batches: map[uint64]*batchTypes.Batch{
+		2: {BatchNumber: 2, BatchL2Data: []byte{0x0b}},
+		3: {BatchNumber: 3, BatchL2Data: forcedBatch.Transactions, ForcedBatchNum: &forcedBatchNumber},
+	}}
+	s, err := New(Config{MaxBatchesForL1: 10, FirstBatchNumber: 2}, nil, nil)
+	require.NoError(t, err)
+	s.source = source
+
+	// The forced batches are required to be watched
+	_, err = s.getSequencesToSend(ctx)
+	require.Error(t, err)
+
+	// The sequence stops before a forced batch not read from L1 yet
+	s.pending = nil
+	reader := &fakeForcedBatchReader{forced: []ethmanTypes.ForcedBatch{forcedBatch}}
+	w := NewForcedBatchWatcher(ForcedBatchesConfig{}, reader, 0)
+	s.SetForcedBatchWatcher(w)
+	sequences, err := s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 1)
+	assert.Equal(t, uint64(2), sequences[0].BatchNumber)
+
+	reader.latestBlock = 10
+	require.NoError(t, w.sync(ctx))
+	sequences, err = s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 1)
+	assert.Equal(t, uint64(3), sequences[0].BatchNumber)
+	assert.Equal(t, forcedBatch.ForcedAt.Unix(), sequences[0].ForcedBatchTimestamp)
+	assert.Equal(t, forcedBatch.GlobalExitRoot, sequences[0].GlobalExitRoot)
+	assert.Equal(t, forcedBatch.PrevBlockHash, sequences[0].PrevBlockHash)
+
+	// The L2 data of the batch must be the forced transactions
+	s.pending = []*batchTypes.Batch{{BatchNumber: 4, BatchL2Data: []byte{0x0c}, ForcedBatchNum: &forcedBatchNumber}}
+	_, err = s.getSequencesToSend(ctx)
+	assert.ErrorContains(t, err, "does not match the transactions of forced batch 1")
+}
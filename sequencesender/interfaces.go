@@ -3,9 +3,19 @@ package sequencesender
 import (
 	"context"
 
+	"github.com/sieniven/zkevm-nubit/etherman"
 	ethmanTypes "github.com/sieniven/zkevm-nubit/etherman/types"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
 )
 
 type dataAbilitier interface {
 	PostSequence(ctx context.Context, sequences []ethmanTypes.Sequence) ([]byte, error)
 }
+
+type l1SequencedBatchReader interface {
+	GetLastSequencedBatch(ctx context.Context, fromBlock uint64) (etherman.SequencedBatchesEvent, error)
+}
+
+type monitoredTxResulter interface {
+	Result(ctx context.Context, owner, id string) (ethtxmanager.MonitoredTxResult, error)
+}
@@ -0,0 +1,83 @@
+package sequencesender
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// LastSequencedBatch is the last sequence handed to the eth tx manager, persisted so the batch
+// numbering resumes after a restart
+type LastSequencedBatch struct {
+	FromBatch uint64    `json:"fromBatch"`
+	ToBatch   uint64    `json:"toBatch"`
+	Time      time.Time `json:"time"`
+	// DAMessage is the data availability message sent with the sequence
+	DAMessage hexutil.Bytes `json:"daMessage,omitempty"`
+	// MonitoredTxID is the ID of the monitored tx sending the sequence to L1
+	MonitoredTxID string `json:"monitoredTxId"`
+	// L1TxHash and L1BlockNumber are set once the tx sending the sequence is mined
+	L1TxHash      *common.Hash `json:"l1TxHash,omitempty"`
+	L1BlockNumber uint64       `json:"l1BlockNumber,omitempty"`
+}
+
+// lastBatchStore keeps the last sequenced batch in a JSON file, an empty path disables it
+type lastBatchStore struct {
+	path string
+}
+
+// newLastBatchStore creates the store of the last sequenced batch, creating the dir of the file
+func newLastBatchStore(path string) (*lastBatchStore, error) {
+	if path != "" {
+		err := os.MkdirAll(filepath.Dir(path), 0o750) //nolint:gomnd
+		if err != nil {
+			return nil, fmt.Errorf("failed to create last batch storage dir: %w", err)
+		}
+	}
+	return &lastBatchStore{path: path}, nil
+}
+
+// Load reads the last sequenced batch, it returns nil if none was stored yet
+func (s *lastBatchStore) Load() (*LastSequencedBatch, error) {
+	if s.path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var last LastSequencedBatch
+	err = json.Unmarshal(raw, &last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode last sequenced batch %s: %w", s.path, err)
+	}
+	return &last, nil
+}
+
+// Store writes the last sequenced batch
+func (s *lastBatchStore) Store(last LastSequencedBatch) error {
+	if s.path == "" {
+		return nil
+	}
+	raw, err := json.Marshal(last)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that the last batch is never partially written
+	tmp := s.path + ".tmp"
+	err = os.WriteFile(tmp, raw, 0o600) //nolint:gomnd
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
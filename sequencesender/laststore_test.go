@@ -0,0 +1,152 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/sieniven/zkevm-nubit/etherman"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeL1Reader returns the last batch sequenced on L1 set by the tests
+type fakeL1Reader struct {
+	event     etherman.SequencedBatchesEvent
+	err       error
+	fromBlock uint64
+}
+
+func (r *fakeL1Reader) GetLastSequencedBatch(ctx context.Context, fromBlock uint64) (etherman.SequencedBatchesEvent, error) {
+	r.fromBlock = fromBlock
+	return r.event, r.err
+}
+
+// fakeMonitoredTxResulter returns the results of the monitored txs set by the tests
+type fakeMonitoredTxResulter map[string]ethtxmanager.MonitoredTxStatus
+
+func (r fakeMonitoredTxResulter) Result(ctx context.Context, owner, id string) (ethtxmanager.MonitoredTxResult, error) {
+	status, ok := r[id]
+	if !ok {
+		return ethtxmanager.MonitoredTxResult{}, ethtxmanager.ErrNotFound
+	}
+	return ethtxmanager.MonitoredTxResult{ID: id, Status: status}, nil
+}
+
+func newTestingRestoreSender(t *testing.T, path string, reader *fakeL1Reader, txResults fakeMonitoredTxResulter) *SequenceSender {
+	t.Helper()
+	s, err := New(Config{
+		FirstBatchNumber:     1,
+		LastBatchStoragePath: path,
+		ReconcileWithL1:      true,
+		L1SyncFromBlock:      100,
+	}, nil, nil)
+	require.NoError(t, err)
+	s.l1Reader = reader
+	s.txResults = txResults
+	return s
+}
+
+func TestLastBatchStore(t *testing.T) {
+	store, err := newLastBatchStore(filepath.Join(t.TempDir(), "sequencesender", "last_batch.json"))
+	require.NoError(t, err)
+
+	last, err := store.Load()
+	require.NoError(t, err)
+	assert.Nil(t, last)
+
+	txHash := common.HexToHash("0x1234")
+	stored := LastSequencedBatch{
+		FromBatch:     3,
+		ToBatch:       5,
+		Time:          time.Unix(1000, 0).UTC(),
+		DAMessage:     []byte{0xca, 0xfe},
+		MonitoredTxID: "sequence-from-3-to-5",
+		L1TxHash:      &txHash,
+		L1BlockNumber: 42,
+	}
+	require.NoError(t, store.Store(stored))
+	last, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, &stored, last)
+
+	// An empty path disables the store
+	store, err = newLastBatchStore("")
+	require.NoError(t, err)
+	require.NoError(t, store.Store(stored))
+	last, err = store.Load()
+	require.NoError(t, err)
+	assert.Nil(t, last)
+}
+
+func TestRestoreLastSequencedBatch(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "last_batch.json")
+
+	txResults := fakeMonitoredTxResulter{}
+
+	// Nothing sequenced yet
+	reader := &fakeL1Reader{err: etherman.ErrNotFound}
+	s := newTestingRestoreSender(t, path, reader, txResults)
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+	assert.Equal(t, uint64(1), s.NextBatchNumber())
+	assert.Equal(t, uint64(100), reader.fromBlock)
+
+	// Sequenced on L1 by another sender, or before persisting the last batch
+	reader = &fakeL1Reader{event: etherman.SequencedBatchesEvent{BatchNumber: 7, BlockNumber: 120}}
+	s = newTestingRestoreSender(t, path, reader, txResults)
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+	assert.Equal(t, uint64(8), s.NextBatchNumber())
+
+	// The persisted batch is ahead of L1 while its tx is not mined yet
+	s.storeLastSequencedBatch(&LastSequencedBatch{FromBatch: 8, ToBatch: 10, MonitoredTxID: "sequence-from-8-to-10"})
+	txResults["sequence-from-8-to-10"] = ethtxmanager.MonitoredTxStatusSent
+	s = newTestingRestoreSender(t, path, reader, txResults)
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+	assert.Equal(t, uint64(11), s.NextBatchNumber())
+	assert.Equal(t, uint64(100), reader.fromBlock)
+
+	// The persisted batch is not trusted once its tx failed, or is unknown to the eth tx manager
+	txResults["sequence-from-8-to-10"] = ethtxmanager.MonitoredTxStatusFailed
+	s = newTestingRestoreSender(t, path, reader, txResults)
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+	assert.Equal(t, uint64(8), s.NextBatchNumber())
+	delete(txResults, "sequence-from-8-to-10")
+	s = newTestingRestoreSender(t, path, reader, txResults)
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+	assert.Equal(t, uint64(8), s.NextBatchNumber())
+
+	// Without reconciling with L1, the persisted batch is the only one known
+	s = newTestingRestoreSender(t, path, reader, txResults)
+	s.cfg.ReconcileWithL1 = false
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+	assert.Equal(t, uint64(11), s.NextBatchNumber())
+
+	// Once mined, L1 is searched from its block
+	txHash := common.HexToHash("0xabcd")
+	s.lastSequencedBatchMined(ethtxmanager.MonitoredTxResult{
+		ID:     "sequence-from-8-to-10",
+		Status: ethtxmanager.MonitoredTxStatusConfirmed,
+		Txs: map[common.Hash]ethtxmanager.TxResult{
+			txHash: {Receipt: &ethTypes.Receipt{Status: ethTypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(130)}},
+		},
+	})
+	reader.event = etherman.SequencedBatchesEvent{BatchNumber: 10, TxHash: txHash, BlockNumber: 130}
+	s = newTestingRestoreSender(t, path, reader, txResults)
+	require.NoError(t, s.RestoreLastSequencedBatch(ctx))
+	assert.Equal(t, uint64(11), s.NextBatchNumber())
+	assert.Equal(t, uint64(130), reader.fromBlock)
+	require.NotNil(t, s.lastSequenced.L1TxHash)
+	assert.Equal(t, txHash, *s.lastSequenced.L1TxHash)
+
+	// L1 errors are not ignored
+	reader.err = errors.New("connection refused")
+	s = newTestingRestoreSender(t, path, reader, txResults)
+	assert.Error(t, s.RestoreLastSequencedBatch(ctx))
+}
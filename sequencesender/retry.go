@@ -0,0 +1,132 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sieniven/zkevm-nubit/etherman"
+	"github.com/sieniven/zkevm-nubit/etherman/types"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/sieniven/zkevm-nubit/log"
+)
+
+// retryMonitoredIDFormat is the ID of the monitored txs sending a sequence again, as the ID of a
+// failed monitored tx can not be reused
+const retryMonitoredIDFormat = monitoredIDFormat + "-retry-%v"
+
+// sentSequence is a sequence sent to L1, kept with its DA message until its tx is confirmed so it
+// can be sent again if the tx fails
+type sentSequence struct {
+	sequences []types.Sequence
+	// daMessage is the data availability message of the sequence, posted at postedAt
+	daMessage []byte
+	postedAt  time.Time
+	// monitoredTxID is the ID of the last monitored tx sending the sequence, and attempts the
+	// number of monitored txs added to send it
+	monitoredTxID string
+	attempts      int
+}
+
+func (sent *sentSequence) fromBatch() uint64 {
+	return sent.sequences[0].BatchNumber
+}
+
+func (sent *sentSequence) toBatch() uint64 {
+	return sent.sequences[len(sent.sequences)-1].BatchNumber
+}
+
+// nextMonitoredTxID returns the ID of the next monitored tx sending the sequence
+func (sent *sentSequence) nextMonitoredTxID() string {
+	if sent.attempts == 0 {
+		return fmt.Sprintf(monitoredIDFormat, sent.fromBatch(), sent.toBatch())
+	}
+	return fmt.Sprintf(retryMonitoredIDFormat, sent.fromBatch(), sent.toBatch(), sent.attempts)
+}
+
+// handleMonitoredTxResult handles the result of a monitored tx sending a sequence, and returns if
+// it failed or was canceled. The sequence of a failed or canceled tx is queued to be sent again with its DA message, or the
+// batches are sequenced again from L1 if the sequence was sent before a restart.
+func (s *SequenceSender) handleMonitoredTxResult(result ethtxmanager.MonitoredTxResult) bool {
+	switch result.Status {
+	case ethtxmanager.MonitoredTxStatusConfirmed:
+		s.lastSequencedBatchMined(result)
+		s.takeSent(result.ID)
+		return false
+
+	case ethtxmanager.MonitoredTxStatusFailed, ethtxmanager.MonitoredTxStatusCanceled:
+		log.Errorf("failed to send sequence in monitored tx %s, %s: %s", result.ID, result.Status, result.FailureReason)
+		sent := s.takeSent(result.ID)
+		if sent == nil {
+			// sent before a restart, the batches are sequenced again from the last batch
+			// sequenced on L1 once the sequences sent after it are done
+			log.Errorf("sequence of failed monitored tx %s not found, rewinding to the last batch sequenced on L1", result.ID)
+			s.rewind = true
+			return true
+		}
+		s.queueResend(sent)
+		return true
+
+	default:
+		return false
+	}
+}
+
+// takeSent removes the sent sequence of the monitored tx from the ones in flight
+func (s *SequenceSender) takeSent(monitoredTxID string) *sentSequence {
+	for i, sent := range s.sent {
+		if sent.monitoredTxID == monitoredTxID {
+			s.sent = append(s.sent[:i], s.sent[i+1:]...)
+			return sent
+		}
+	}
+	return nil
+}
+
+// queueResend queues the sequence to be sent again, keeping the queue in batch order as each
+// sequence is sent on top of the previous one
+func (s *SequenceSender) queueResend(sent *sentSequence) {
+	s.resend = append(s.resend, sent)
+	sort.SliceStable(s.resend, func(i, j int) bool {
+		return s.resend[i].fromBatch() < s.resend[j].fromBatch()
+	})
+}
+
+// reuseDAMessage returns if the DA message of the sequence can be sent again, as it was posted
+// within DAMessageMaxAge. Older messages are posted again as their proof may have expired.
+func (s *SequenceSender) reuseDAMessage(sent *sentSequence) bool {
+	maxAge := s.cfg.DAMessageMaxAge.Duration
+	return maxAge > 0 && s.clock.Now().Sub(sent.postedAt) < maxAge
+}
+
+// rewindToL1 sets the next batch to sequence following the last batch sequenced on L1, when a
+// sequence sent before a restart failed. The pending batches and the sequences queued to be sent
+// again follow the lost sequence, so they are dropped and read from the batch source again.
+func (s *SequenceSender) rewindToL1(ctx context.Context) error {
+	if s.l1Reader == nil {
+		return errors.New("no L1 reader to get the last batch sequenced on L1")
+	}
+	fromBlock := s.cfg.L1SyncFromBlock
+	if s.lastSequenced != nil && s.lastSequenced.L1BlockNumber > fromBlock {
+		fromBlock = s.lastSequenced.L1BlockNumber
+	}
+	nextBatchNum := s.cfg.FirstBatchNumber
+	event, err := s.l1Reader.GetLastSequencedBatch(ctx, fromBlock)
+	if err != nil && !errors.Is(err, etherman.ErrNotFound) {
+		return fmt.Errorf("failed to get last batch sequenced on L1: %w", err)
+	} else if err == nil && event.BatchNumber+1 > nextBatchNum {
+		nextBatchNum = event.BatchNumber + 1
+	}
+
+	log.Warnf("sequencing the batches again from batch %d, following the last batch sequenced on L1", nextBatchNum)
+	s.mutex.Lock()
+	s.lastBatchNum = nextBatchNum
+	s.mutex.Unlock()
+	s.pending = nil
+	s.resend = nil
+	s.nextForcedBatch = 0
+	s.rewind = false
+	return nil
+}
@@ -0,0 +1,116 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/sieniven/zkevm-nubit/etherman"
+	ethmanTypes "github.com/sieniven/zkevm-nubit/etherman/types"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	batchTypes "github.com/sieniven/zkevm-nubit/sequencesender/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestingSentSequence(fromBatch, toBatch uint64, monitoredTxID string) *sentSequence {
+	sent := &sentSequence{monitoredTxID: monitoredTxID, daMessage: []byte{byte(fromBatch)}, attempts: 1}
+	for batch := fromBatch; batch <= toBatch; batch++ {
+		sent.sequences = append(sent.sequences, ethmanTypes.Sequence{BatchNumber: batch})
+	}
+	return sent
+}
+
+func TestNextMonitoredTxID(t *testing.T) {
+	sent := newTestingSentSequence(3, 5, "")
+	sent.attempts = 0
+	assert.Equal(t, "sequence-from-3-to-5", sent.nextMonitoredTxID())
+	sent.attempts = 2
+	assert.Equal(t, "sequence-from-3-to-5-retry-2", sent.nextMonitoredTxID())
+}
+
+func TestFailedSequencesAreSentAgainInOrder(t *testing.T) {
+	s, err := New(Config{}, nil, nil)
+	require.NoError(t, err)
+	first := newTestingSentSequence(1, 2, "sequence-from-1-to-2")
+	second := newTestingSentSequence(3, 4, "sequence-from-3-to-4")
+	third := newTestingSentSequence(5, 6, "sequence-from-5-to-6")
+	s.sent = []*sentSequence{first, second, third}
+
+	// The sequences sent after a failed one fail too, in any order, and a canceled one is
+	// sent again like a failed one
+	assert.True(t, s.handleMonitoredTxResult(ethtxmanager.MonitoredTxResult{
+		ID: third.monitoredTxID, Status: ethtxmanager.MonitoredTxStatusFailed, FailureReason: "reverted",
+	}))
+	assert.True(t, s.handleMonitoredTxResult(ethtxmanager.MonitoredTxResult{
+		ID: second.monitoredTxID, Status: ethtxmanager.MonitoredTxStatusCanceled,
+	}))
+	assert.Equal(t, []*sentSequence{first}, s.sent)
+	assert.Equal(t, []*sentSequence{second, third}, s.resend)
+	assert.Equal(t, []byte{3}, s.resend[0].daMessage)
+
+	assert.False(t, s.handleMonitoredTxResult(ethtxmanager.MonitoredTxResult{
+		ID: first.monitoredTxID, Status: ethtxmanager.MonitoredTxStatusConfirmed,
+	}))
+	assert.Empty(t, s.sent)
+
+	// The failed txs sent before a restart are unknown, the batches are sequenced from L1 again
+	assert.True(t, s.handleMonitoredTxResult(ethtxmanager.MonitoredTxResult{
+		ID: "sequence-from-7-to-8", Status: ethtxmanager.MonitoredTxStatusFailed,
+	}))
+	assert.Len(t, s.resend, 2)
+	assert.True(t, s.rewind)
+}
+
+func TestRewindToL1(t *testing.T) {
+	ctx := context.Background()
+	reader := &fakeL1Reader{event: etherman.SequencedBatchesEvent{BatchNumber: 6, BlockNumber: 120}}
+	s, err := New(Config{FirstBatchNumber: 1, L1SyncFromBlock: 100}, nil, nil)
+	require.NoError(t, err)
+	s.l1Reader = reader
+	s.lastBatchNum = 11
+	s.pending = []*batchTypes.Batch{{BatchNumber: 11}}
+	s.resend = []*sentSequence{newTestingSentSequence(9, 10, "sequence-from-9-to-10")}
+	s.nextForcedBatch = 3
+	s.rewind = true
+
+	require.NoError(t, s.rewindToL1(ctx))
+	assert.Equal(t, uint64(7), s.NextBatchNumber())
+	assert.Equal(t, uint64(100), reader.fromBlock)
+	assert.Empty(t, s.pending)
+	assert.Empty(t, s.resend)
+	assert.Zero(t, s.nextForcedBatch)
+	assert.False(t, s.rewind)
+
+	// Nothing sequenced on L1 yet
+	reader.err = etherman.ErrNotFound
+	require.NoError(t, s.rewindToL1(ctx))
+	assert.Equal(t, uint64(1), s.NextBatchNumber())
+
+	// L1 errors are retried
+	reader.err = errors.New("connection refused")
+	s.rewind = true
+	assert.Error(t, s.rewindToL1(ctx))
+	assert.True(t, s.rewind)
+}
+
+func TestReuseDAMessage(t *testing.T) {
+	s, err := New(Config{DAMessageMaxAge: types.NewDuration(10 * time.Minute)}, nil, nil)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	s.clock = clock
+	sent := newTestingSentSequence(1, 1, "")
+	sent.postedAt = clock.now
+
+	clock.now = clock.now.Add(9 * time.Minute)
+	assert.True(t, s.reuseDAMessage(sent))
+	clock.now = clock.now.Add(time.Minute)
+	assert.False(t, s.reuseDAMessage(sent))
+
+	// A zero age always posts the DA message again
+	s.cfg.DAMessageMaxAge = types.NewDuration(0)
+	clock.now = sent.postedAt
+	assert.False(t, s.reuseDAMessage(sent))
+}
@@ -3,14 +3,20 @@ package sequencesender
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/sieniven/zkevm-nubit/etherman"
 	"github.com/sieniven/zkevm-nubit/etherman/types"
 	"github.com/sieniven/zkevm-nubit/ethtxmanager"
 	"github.com/sieniven/zkevm-nubit/log"
+	"github.com/sieniven/zkevm-nubit/sequencesender/batchsource"
 	batchTypes "github.com/sieniven/zkevm-nubit/sequencesender/types"
 )
 
@@ -18,6 +24,28 @@ const (
 	ethTxManagerOwner                = "sequencer"
 	monitoredIDFormat                = "sequence-from-%v-to-%v"
 	sendSequnceFlagTriggerBufferSize = 5
+	// daHistorySize is the number of DA submissions kept in the history
+	daHistorySize = 100
+)
+
+// daMessageSizeEstimate is the size of the data availability message assumed when estimating the
+// size of the L1 tx, as the message is only known once the sequence is posted to the DA layer. It
+// is an upper bound of the NubitDA and Ethereum blobs messages.
+const daMessageSizeEstimate = 1024
+
+var (
+	// ErrInvalidBatchLimits when setting a zero batch count or batch size
+	ErrInvalidBatchLimits = errors.New("max batches and max batch bytes size must be greater than zero")
+	// ErrOversizedData is returned if the input data of a transaction is greater
+	// than a maximum size that is considered too large to be sent to L1
+	ErrOversizedData = errors.New("oversized data")
+	// ErrPendingSequencesWithoutForcedGas when sending more than one sequence in flight without
+	// the gas forced by the eth tx manager, as the gas estimation fails while the previous
+	// sequences are not mined
+	ErrPendingSequencesWithoutForcedGas = errors.New("more than one pending sequence requires EthTxManager.ForcedGas")
+	// ErrForcedBatchesInjectedIntoSource when injecting the forced batches into the batches of a
+	// source other than the synthetic one, which include the forced batches themselves
+	ErrForcedBatchesInjectedIntoSource = errors.New("forced batches can only be injected into the synthetic batch source")
 )
 
 type SequenceSender struct {
@@ -25,23 +53,208 @@ type SequenceSender struct {
 	ethTxManager     *ethtxmanager.Client
 	etherman         *etherman.Client
 	sendSequenceFlag atomic.Bool
-	lastBatchNum     uint64
+	paused           atomic.Bool
+
+	// mutex protects the batch limits of the config, the last batch number and the DA history
+	mutex        sync.Mutex
+	lastBatchNum uint64
+	daHistory    []DASubmission
+
+	// source of the batches to sequence
+	source batchsource.BatchSource
+	// pending are the batches read from the source and not sequenced yet, following the last
+	// batch number. They are only accessed by the sending loop.
+	pending []*batchTypes.Batch
+	// clock tells the time to the sequence closing triggers
+	clock clock
+	// gasPricer tells the L1 gas price to the sequence closing triggers
+	gasPricer l1GasPricer
+
+	// lastBatchStore persists the last sequenced batch, which is only accessed by the sending loop
+	lastBatchStore *lastBatchStore
+	lastSequenced  *LastSequencedBatch
+	// sent are the sequences in flight, in the order they were sent, and resend the ones whose L1
+	// tx failed, in batch order. They are only accessed by the sending loop.
+	sent   []*sentSequence
+	resend []*sentSequence
+	// draining is set when a sequence failed, until the sequences sent after it are done. It is
+	// only accessed by the sending loop.
+	draining bool
+	// rewind is set when a sequence sent before a restart failed, so the batches are sequenced
+	// again from the last batch sequenced on L1. It is only accessed by the sending loop.
+	rewind bool
+	// l1Reader reads the last batch sequenced on L1 to reconcile the last sequenced batch with
+	l1Reader l1SequencedBatchReader
+	// txResults tells if the monitored tx of the persisted last sequenced batch is still pending
+	txResults monitoredTxResulter
 
 	// data availability layer
 	da dataAbilitier
+
+	// forcedBatches follows the batches forced on L1, nil when they are not sequenced
+	forcedBatches *ForcedBatchWatcher
+	// nextForcedBatch is the next forced batch to inject into the pending batches, zero until it
+	// is known from L1. It is only accessed by the sending loop.
+	nextForcedBatch uint64
+}
+
+// DASubmission is the result of posting a sequence to the data availability layer
+type DASubmission struct {
+	FromBatch uint64    `json:"fromBatch"`
+	ToBatch   uint64    `json:"toBatch"`
+	Time      time.Time `json:"time"`
+	// Message is the data availability message returned by the DA layer
+	Message hexutil.Bytes `json:"message,omitempty"`
+	Error   string        `json:"error,omitempty"`
 }
 
 // New inits sequence sender
 func New(cfg Config, etherman *etherman.Client, manager *ethtxmanager.Client) (*SequenceSender, error) {
-	s := SequenceSender{
+	if manager != nil && cfg.MaxPendingSequences > 1 && manager.ForcedGas() == 0 {
+		return nil, ErrPendingSequencesWithoutForcedGas
+	}
+	forcedBatches := cfg.ForcedBatches
+	if forcedBatches.Enabled && forcedBatches.Inject && cfg.BatchSource.Type != batchsource.TypeSynthetic && cfg.BatchSource.Type != "" {
+		return nil, ErrForcedBatchesInjectedIntoSource
+	}
+	s := &SequenceSender{
 		cfg:          cfg,
 		etherman:     etherman,
 		ethTxManager: manager,
-		lastBatchNum: 0,
+		lastBatchNum: cfg.FirstBatchNumber,
+		clock:        systemClock{},
+	}
+	if etherman != nil {
+		s.gasPricer = etherman
+		s.l1Reader = etherman
+	}
+	if manager != nil {
+		s.txResults = manager
 	}
 	s.sendSequenceFlag.Store(false)
 
-	return &s, nil
+	store, err := newLastBatchStore(cfg.LastBatchStoragePath)
+	if err != nil {
+		return nil, err
+	}
+	s.lastBatchStore = store
+
+	source, err := batchsource.New(cfg.BatchSource, cfg.FirstBatchNumber, cfg.L2Coinbase, func() uint64 {
+		_, maxBatchBytesSize := s.BatchLimits()
+		return maxBatchBytesSize
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch source: %w", err)
+	}
+	s.source = source
+
+	return s, nil
+}
+
+// RestoreLastSequencedBatch sets the next batch to sequence following the last sequenced batch.
+// The batches persisted by a previous run are only resumed after while the monitored tx sending
+// them is still pending, otherwise the next batch follows the last one sequenced on L1. It must
+// be called before starting the sequence sender.
+func (s *SequenceSender) RestoreLastSequencedBatch(ctx context.Context) error {
+	last, err := s.lastBatchStore.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load last sequenced batch: %w", err)
+	}
+	s.lastSequenced = last
+
+	reconcile := s.cfg.ReconcileWithL1 && s.l1Reader != nil
+	nextBatchNum := s.cfg.FirstBatchNumber
+	fromBlock := s.cfg.L1SyncFromBlock
+	if last != nil {
+		log.Infof("last sequenced batch %d, sent in monitored tx %s", last.ToBatch, last.MonitoredTxID)
+		pending, err := s.isLastSequencedBatchPending(ctx, last)
+		if err != nil {
+			return err
+		}
+		if pending || !reconcile {
+			// without L1 to reconcile with, the persisted batch is the only one known
+			if last.ToBatch+1 > nextBatchNum {
+				nextBatchNum = last.ToBatch + 1
+			}
+		} else if last.L1TxHash == nil {
+			log.Warnf("monitored tx %s of last sequenced batch %d is not pending, resuming after the last batch sequenced on L1",
+				last.MonitoredTxID, last.ToBatch)
+		}
+		if last.L1BlockNumber > fromBlock {
+			fromBlock = last.L1BlockNumber
+		}
+	}
+
+	if reconcile {
+		event, err := s.l1Reader.GetLastSequencedBatch(ctx, fromBlock)
+		if errors.Is(err, etherman.ErrNotFound) {
+			log.Infof("no batches sequenced on L1 since block %d", fromBlock)
+		} else if err != nil {
+			return fmt.Errorf("failed to get last batch sequenced on L1: %w", err)
+		} else {
+			log.Infof("last batch sequenced on L1 %d, in tx %s of block %d", event.BatchNumber, event.TxHash, event.BlockNumber)
+			if event.BatchNumber+1 > nextBatchNum {
+				nextBatchNum = event.BatchNumber + 1
+			}
+		}
+	}
+
+	s.mutex.Lock()
+	s.lastBatchNum = nextBatchNum
+	s.mutex.Unlock()
+	s.pending = nil
+	s.nextForcedBatch = 0
+	log.Infof("next batch to sequence %d", nextBatchNum)
+	return nil
+}
+
+// isLastSequencedBatchPending checks if the monitored tx sending the last sequenced batch is still
+// pending in the eth tx manager, so its batches may still be sequenced on L1
+func (s *SequenceSender) isLastSequencedBatchPending(ctx context.Context, last *LastSequencedBatch) (bool, error) {
+	if last.L1TxHash != nil || s.txResults == nil {
+		return false, nil
+	}
+	result, err := s.txResults.Result(ctx, ethTxManagerOwner, last.MonitoredTxID)
+	if errors.Is(err, ethtxmanager.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get monitored tx %s of last sequenced batch: %w", last.MonitoredTxID, err)
+	}
+	switch result.Status {
+	case ethtxmanager.MonitoredTxStatusCreated, ethtxmanager.MonitoredTxStatusSent, ethtxmanager.MonitoredTxStatusCanceling:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// storeLastSequencedBatch persists the last sequenced batch, it is not fatal if it fails as the
+// next start reconciles it with L1
+func (s *SequenceSender) storeLastSequencedBatch(last *LastSequencedBatch) {
+	s.lastSequenced = last
+	err := s.lastBatchStore.Store(*last)
+	if err != nil {
+		log.Errorf("failed to store last sequenced batch %d: %v", last.ToBatch, err)
+	}
+}
+
+// lastSequencedBatchMined records the L1 tx of the last sequenced batch once the monitored tx
+// sending it is mined
+func (s *SequenceSender) lastSequencedBatchMined(result ethtxmanager.MonitoredTxResult) {
+	last := s.lastSequenced
+	if last == nil || last.L1TxHash != nil || result.ID != last.MonitoredTxID {
+		return
+	}
+	for txHash, txResult := range result.Txs {
+		if txResult.Receipt == nil || txResult.Receipt.Status != ethTypes.ReceiptStatusSuccessful {
+			continue
+		}
+		mined := *last
+		mined.L1TxHash = &txHash
+		mined.L1BlockNumber = txResult.Receipt.BlockNumber.Uint64()
+		s.storeLastSequencedBatch(&mined)
+		return
+	}
 }
 
 // SetDataProvider sets the data provider
@@ -49,121 +262,418 @@ func (s *SequenceSender) SetDataProvider(da dataAbilitier) {
 	s.da = da
 }
 
+// SetForcedBatchWatcher sets the watcher of the batches forced on L1, which are then sequenced
+// with the data committed by the rollup
+func (s *SequenceSender) SetForcedBatchWatcher(w *ForcedBatchWatcher) {
+	s.forcedBatches = w
+}
+
+// TriggerSend sets the sequence sender to send a sequence on its next cycle, or as soon as
+// it is resumed if paused
+func (s *SequenceSender) TriggerSend() {
+	s.sendSequenceFlag.Store(true)
+}
+
+// Pause stops sending sequences until resumed, the pending monitored txs keep being monitored
+func (s *SequenceSender) Pause() {
+	s.paused.Store(true)
+}
+
+// Resume sends sequences again after being paused
+func (s *SequenceSender) Resume() {
+	s.paused.Store(false)
+}
+
+// IsPaused returns if sending sequences is paused
+func (s *SequenceSender) IsPaused() bool {
+	return s.paused.Load()
+}
+
+// IsSendTriggered returns if a sequence is waiting to be sent
+func (s *SequenceSender) IsSendTriggered() bool {
+	return s.sendSequenceFlag.Load()
+}
+
+// SetBatchLimits sets the number of batches of the sequences and the size of the batches sent
+// from the next sequence on
+func (s *SequenceSender) SetBatchLimits(maxBatchesForL1, maxBatchBytesSize uint64) error {
+	if maxBatchesForL1 == 0 || maxBatchBytesSize == 0 {
+		return ErrInvalidBatchLimits
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cfg.MaxBatchesForL1 = maxBatchesForL1
+	s.cfg.MaxBatchBytesSize = maxBatchBytesSize
+	return nil
+}
+
+// BatchLimits returns the number of batches of the sequences and the size of the batches sent
+func (s *SequenceSender) BatchLimits() (maxBatchesForL1, maxBatchBytesSize uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cfg.MaxBatchesForL1, s.cfg.MaxBatchBytesSize
+}
+
+// NextBatchNumber returns the number of the next batch to be sequenced
+func (s *SequenceSender) NextBatchNumber() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.lastBatchNum
+}
+
+// PendingMonitoredTxs returns the results of the sequences txs not confirmed yet on L1
+func (s *SequenceSender) PendingMonitoredTxs(ctx context.Context) ([]ethtxmanager.MonitoredTxResult, error) {
+	return s.ethTxManager.ResultsByStatus(ctx, ethTxManagerOwner, []ethtxmanager.MonitoredTxStatus{
+		ethtxmanager.MonitoredTxStatusCreated,
+		ethtxmanager.MonitoredTxStatusSent,
+		ethtxmanager.MonitoredTxStatusCanceling,
+	})
+}
+
+// DAHistory returns the latest submissions of sequences to the data availability layer, oldest first
+func (s *SequenceSender) DAHistory() []DASubmission {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	history := make([]DASubmission, len(s.daHistory))
+	copy(history, s.daHistory)
+	return history
+}
+
+// recordDASubmission adds the submission to the DA history, dropping the oldest one when full
+func (s *SequenceSender) recordDASubmission(submission DASubmission) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.daHistory) == daHistorySize {
+		s.daHistory = s.daHistory[1:]
+	}
+	s.daHistory = append(s.daHistory, submission)
+}
+
+// SendSequenceHandle triggers sending a sequence every time 's' is read from the reader, until
+// the context is done or the reader is closed
 func (s *SequenceSender) SendSequenceHandle(ctx context.Context, reader *bufio.Reader) {
-	for {
+	for ctx.Err() == nil {
 		char, _, err := reader.ReadRune()
-		if err != nil {
+		if errors.Is(err, io.EOF) {
+			return
+		} else if err != nil {
 			fmt.Println(err)
 		} else if char == 's' {
-			s.sendSequenceFlag.Store(true)
+			s.TriggerSend()
 		} else {
 			fmt.Println("unknown command received, skippping")
 		}
-		time.Sleep(time.Second)
+		sleep(ctx, time.Second)
+	}
+}
+
+// Start sends sequences until the context is done, or until a batch can not be sequenced at all.
+// A sequence being sent when the context is done is still posted to the DA layer and added to
+// the eth tx manager before returning, so its batches are not lost.
+func (s *SequenceSender) Start(ctx context.Context) error {
+	for ctx.Err() == nil {
+		err := s.tryToSendSequence(ctx)
+		if err != nil {
+			log.Errorf("sequence sender stopped: %v", err)
+			return err
+		}
 	}
+	log.Info("sequence sender stopped")
+	return nil
 }
 
-func (s *SequenceSender) Start(ctx context.Context) {
-	for {
-		s.tryToSendSequence(ctx)
+// sleep waits for the duration, or until the context is done
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
 	}
 }
 
-func (s *SequenceSender) tryToSendSequence(ctx context.Context) {
-	retry := false
-	// process monitored sequences before starting a next cycle
-	s.ethTxManager.ProcessPendingMonitoredTxs(ctx, ethTxManagerOwner, func(result ethtxmanager.MonitoredTxResult) {
-		if result.Status == ethtxmanager.MonitoredTxStatusFailed {
-			retry = true
-			fmt.Println("failed to send sequence")
+// tryToSendSequence runs a cycle of the sequence sender, it returns an error if the next batch can
+// never be sequenced
+func (s *SequenceSender) tryToSendSequence(ctx context.Context) error {
+	// process the monitored sequences without waiting for the ones pending on L1, so the next
+	// sequence is posted to the DA layer meanwhile
+	failed := false
+	inFlight, err := s.ethTxManager.ProcessMonitoredTxs(ctx, ethTxManagerOwner, func(result ethtxmanager.MonitoredTxResult) {
+		if s.handleMonitoredTxResult(result) {
+			failed = true
 		}
 	})
+	if err != nil {
+		fmt.Printf("error processing monitored sequences: %v\n", err)
+		sleep(ctx, time.Second)
+		return nil
+	}
 
-	if retry {
-		return
+	if !s.pipelineReady(inFlight, failed) || s.paused.Load() {
+		sleep(ctx, time.Second)
+		return nil
+	}
+
+	// The sequence of a failed tx sent before a restart is unknown, so its batches are read again
+	if s.rewind {
+		err := s.rewindToL1(ctx)
+		if err != nil {
+			fmt.Printf("error rewinding to the last batch sequenced on L1: %v\n", err)
+			sleep(ctx, time.Second)
+			return nil
+		}
+	}
+
+	// Send again the sequences whose L1 tx failed before any new one
+	if len(s.resend) > 0 {
+		sent := s.resend[0]
+		fmt.Printf("sending failed sequences to L1 again. From batch %d to batch %d\n", sent.fromBatch(), sent.toBatch())
+		err := s.sendSequence(context.WithoutCancel(ctx), sent)
+		if err != nil {
+			fmt.Printf("error sending sequences again: %v\n", err)
+			sleep(ctx, s.cfg.WaitPeriodSendSequence.Duration)
+			return nil
+		}
+		s.resend = s.resend[1:]
+		return nil
 	}
 
 	// Check if should send mock sequence to L1
-	if s.sendSequenceFlag.Load() {
+	if s.sendSequenceFlag.Load() || s.shouldCloseSequence(ctx) {
 		fmt.Println("getting sequences to send")
 		s.sendSequenceFlag.Store(false)
 
-		sequences, err := s.getMockSequencesToSend()
+		sequences, err := s.getSequencesToSend(ctx)
+		if errors.Is(err, ErrOversizedData) {
+			// TODO: gracefully handle this situation by creating an L2 reorg
+			return err
+		}
 		if err != nil || len(sequences) == 0 {
 			if err != nil {
 				fmt.Printf("error getting sequences: %v\n", err)
 			} else {
 				fmt.Println("waiting for sequences to be worth sending to L1")
 			}
-			time.Sleep(s.cfg.WaitPeriodSendSequence.Duration)
-			return
+			sleep(ctx, s.cfg.WaitPeriodSendSequence.Duration)
+			return nil
 		}
 
-		sequenceCount := len(sequences)
-		firstSequence := sequences[0]
-		lastSequence := sequences[sequenceCount-1]
-		fmt.Printf("sending sequences to L1. From batch %d to batch %d\n", firstSequence.BatchNumber, lastSequence.BatchNumber)
+		sent := &sentSequence{sequences: sequences}
+		fmt.Printf("sending sequences to L1. From batch %d to batch %d\n", sent.fromBatch(), sent.toBatch())
+		err = s.sendSequence(context.WithoutCancel(ctx), sent)
+		if err != nil {
+			// the batches were already taken from the pending ones, so they are sent again
+			fmt.Printf("error sending sequences: %v\n", err)
+			s.queueResend(sent)
+		}
+	} else {
+		// No sequnce to send
+		sleep(ctx, time.Second)
+	}
+	return nil
+}
 
-		// Add sequence to be monitored
-		daMessage, err := s.da.PostSequence(ctx, sequences)
+// sendSequence posts the sequence to the DA layer, unless its DA message can be reused, and adds
+// the tx sending it to L1 to the eth tx manager. It is not canceled on shutdown, so the sequence
+// is drained once started.
+func (s *SequenceSender) sendSequence(ctx context.Context, sent *sentSequence) error {
+	if onlyForcedBatches(sent.sequences) {
+		// the rollup only verifies the DA message of the batches that are not forced
+		sent.daMessage = []byte{}
+	} else if sent.daMessage != nil && s.reuseDAMessage(sent) {
+		log.Infof("reusing the data availability message posted at %v for batches %d to %d", sent.postedAt, sent.fromBatch(), sent.toBatch())
+	} else {
+		daMessage, err := s.da.PostSequence(ctx, sent.sequences)
+		submission := DASubmission{
+			FromBatch: sent.fromBatch(),
+			ToBatch:   sent.toBatch(),
+			Time:      time.Now(),
+			Message:   daMessage,
+		}
 		if err != nil {
-			fmt.Printf("error posting sequences to the data availability protocol: %v\n", err)
-			return
+			submission.Error = err.Error()
 		}
-		to, data, err := s.etherman.BuildMockSequenceBatchesTxData(
-			s.cfg.SenderAddress, sequences, uint64(lastSequence.LastL2BLockTimestamp), firstSequence.BatchNumber-1, s.cfg.L2Coinbase, daMessage)
+		s.recordDASubmission(submission)
 		if err != nil {
-			fmt.Printf("error estimating new sequenceBatches to add to eth tx manager: %v\n", err)
-			return
+			return fmt.Errorf("error posting sequences to the data availability protocol: %w", err)
 		}
+		sent.daMessage = daMessage
+		sent.postedAt = s.clock.Now()
+	}
 
-		monitoredTxID := fmt.Sprintf(monitoredIDFormat, firstSequence.BatchNumber, lastSequence.BatchNumber)
-		err = s.ethTxManager.Add(ctx, ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to, nil, data, s.cfg.GasOffset)
-		if err != nil {
-			fmt.Printf("error to add sequences tx to eth tx manager: %v\n", err)
-			return
+	firstSequence := sent.sequences[0]
+	lastSequence := sent.sequences[len(sent.sequences)-1]
+	to, data, err := s.etherman.BuildMockSequenceBatchesTxData(
+		s.cfg.SenderAddress, sent.sequences, uint64(lastSequence.LastL2BLockTimestamp), firstSequence.BatchNumber-1, s.cfg.L2Coinbase, sent.daMessage)
+	if err != nil {
+		return fmt.Errorf("error estimating new sequenceBatches to add to eth tx manager: %w", err)
+	}
+
+	monitoredTxID := sent.nextMonitoredTxID()
+	err = s.ethTxManager.Add(ctx, ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to, nil, data, s.cfg.GasOffset)
+	if err != nil {
+		return fmt.Errorf("error to add sequences tx to eth tx manager: %w", err)
+	}
+	sent.monitoredTxID = monitoredTxID
+	sent.attempts++
+	s.sent = append(s.sent, sent)
+
+	s.storeLastSequencedBatch(&LastSequencedBatch{
+		FromBatch:     firstSequence.BatchNumber,
+		ToBatch:       lastSequence.BatchNumber,
+		Time:          time.Now(),
+		DAMessage:     sent.daMessage,
+		MonitoredTxID: monitoredTxID,
+	})
+	return nil
+}
+
+// pipelineReady returns if a sequence can be posted with inFlight sequences pending on L1. The
+// number of in-flight sequences is bounded by MaxPendingSequences. Their L1 txs are sent in order
+// as the eth tx manager assigns the nonces in the order they are added. The sequences sent after a
+// failed one build on it and are going to fail too, so no sequence is posted until they are done.
+func (s *SequenceSender) pipelineReady(inFlight int, failed bool) bool {
+	if failed {
+		s.draining = true
+	}
+	if s.draining {
+		if inFlight > 0 {
+			log.Debugf("waiting for %d sequences sent after a failed one to be done", inFlight)
+			return false
 		}
-	} else {
-		// No sequnce to send
-		time.Sleep(time.Second)
+		s.draining = false
+		return false
 	}
+
+	maxPendingSequences := s.cfg.MaxPendingSequences
+	if maxPendingSequences == 0 {
+		maxPendingSequences = 1
+	}
+	if uint64(inFlight) >= maxPendingSequences {
+		log.Debugf("waiting for one of the %d in-flight sequences to be confirmed", inFlight)
+		return false
+	}
+	return true
 }
 
-// getMockSequencesToSend is a mock function to replicate Polygon CDK's getSequencesToSend.
-// We generate mock batches with max batch data bytes size and convert them into sequences.
-func (s *SequenceSender) getMockSequencesToSend() ([]types.Sequence, error) {
-	// Generate mock batch data for max configured size
-	data := make([]byte, s.cfg.MaxBatchBytesSize)
-	for i := uint64(0); i < s.cfg.MaxBatchBytesSize; i++ {
-		data[i] = byte(10)
+// getSequencesToSend replicates Polygon CDK's getSequencesToSend. The batches following the last
+// sequenced batch are read from the batch source until MaxBatchesForL1 is reached, the next batch
+// is not available yet, or the next batch would make the L1 tx exceed MaxTxSizeForL1. A single
+// batch too big to be sent is not consumed and returns ErrOversizedData.
+func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequence, error) {
+	maxBatchesForL1, _ := s.BatchLimits()
+	err := s.readPendingBatches(ctx, maxBatchesForL1)
+	if err != nil {
+		return nil, err
 	}
 
 	sequences := []types.Sequence{}
 
 	// Add sequences until too big for a single L1 tx or last batch is reached
-	for {
-		// Create mock batch
-		batch := batchTypes.Batch{
-			BatchNumber: uint64(s.lastBatchNum),
-			Coinbase:    s.cfg.L2Coinbase,
-			BatchL2Data: data,
-			Timestamp:   time.Now(),
+	for _, batch := range s.pending {
+		if uint64(len(sequences)) == maxBatchesForL1 {
+			break
 		}
-		s.lastBatchNum += 1
 
 		seq := types.Sequence{
+			GlobalExitRoot:       batch.GlobalExitRoot,
+			StateRoot:            batch.StateRoot,
+			LocalExitRoot:        batch.LocalExitRoot,
+			AccInputHash:         batch.AccInputHash,
 			BatchL2Data:          batch.BatchL2Data,
 			BatchNumber:          batch.BatchNumber,
 			LastL2BLockTimestamp: batch.Timestamp.Unix(),
 		}
+		if batch.ForcedBatchNum != nil {
+			forcedBatch, err := s.getForcedBatch(batch)
+			if errors.Is(err, errForcedBatchNotRead) {
+				log.Infof("waiting for forced batch %d of batch %d to be read from L1", *batch.ForcedBatchNum, batch.BatchNumber)
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			seq.GlobalExitRoot = forcedBatch.GlobalExitRoot
+			seq.ForcedBatchTimestamp = forcedBatch.ForcedAt.Unix()
+			seq.PrevBlockHash = forcedBatch.PrevBlockHash
+		}
 		sequences = append(sequences, seq)
-		if len(sequences) == int(s.cfg.MaxBatchesForL1) {
+
+		// Check if can be sent
+		err = s.checkSequencesTxSize(sequences)
+		if errors.Is(err, ErrOversizedData) {
+			if len(sequences) == 1 {
+				return nil, fmt.Errorf("%w: batch %d is too big to be sent to L1, even when it's the only one in the sequence",
+					err, batch.BatchNumber)
+			}
+			// Remove the latest item and send the sequences
 			log.Infof(
-				"sequence should be sent to L1, because MaxBatchesForL1 (%d) has been reached",
-				s.cfg.MaxBatchesForL1,
+				"done building sequences, selected batches to %d. Batch %d caused the L1 tx to be too big",
+				batch.BatchNumber-1, batch.BatchNumber,
 			)
+			sequences = sequences[:len(sequences)-1]
+			sequences[len(sequences)-1].IsSequenceTooBig = true
 			break
 		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if uint64(len(sequences)) == maxBatchesForL1 {
+		log.Infof(
+			"sequence should be sent to L1, because MaxBatchesForL1 (%d) has been reached",
+			maxBatchesForL1,
+		)
 	}
+
+	s.pending = s.pending[len(sequences):]
+	s.mutex.Lock()
+	s.lastBatchNum += uint64(len(sequences))
+	s.mutex.Unlock()
 	return sequences, nil
 }
+
+// readPendingBatches reads the batches following the pending ones from the batch source, until
+// there are maxBatches pending batches or the next batch is not available yet
+func (s *SequenceSender) readPendingBatches(ctx context.Context, maxBatches uint64) error {
+	nextBatchNum := s.NextBatchNumber() + uint64(len(s.pending))
+	for uint64(len(s.pending)) < maxBatches {
+		if forcedBatch := s.nextForcedBatchToInject(nextBatchNum); forcedBatch != nil {
+			log.Infof("injecting forced batch %d as batch %d", *forcedBatch.ForcedBatchNum, nextBatchNum)
+			s.pending = append(s.pending, forcedBatch)
+			nextBatchNum++
+			continue
+		}
+		batch, err := s.source.GetBatch(ctx, nextBatchNum)
+		if errors.Is(err, batchsource.ErrBatchNotAvailable) {
+			log.Debugf("batch %d not available yet, %d batches pending", nextBatchNum, len(s.pending))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.pending = append(s.pending, batch)
+		nextBatchNum++
+	}
+	return nil
+}
+
+// checkSequencesTxSize returns ErrOversizedData if the estimated size of the L1 tx sending the
+// sequences exceeds MaxTxSizeForL1. A zero MaxTxSizeForL1 disables the check.
+func (s *SequenceSender) checkSequencesTxSize(sequences []types.Sequence) error {
+	if s.cfg.MaxTxSizeForL1 == 0 {
+		return nil
+	}
+	firstSequence := sequences[0]
+	lastSequence := sequences[len(sequences)-1]
+	txSize, err := etherman.EstimateSequenceBatchesTxSize(sequences, uint64(lastSequence.LastL2BLockTimestamp),
+		firstSequence.BatchNumber-1, s.cfg.L2Coinbase, make([]byte, daMessageSizeEstimate))
+	if err != nil {
+		return fmt.Errorf("failed to estimate sequences tx size: %w", err)
+	}
+	if txSize > s.cfg.MaxTxSizeForL1 {
+		log.Infof("oversized data on sequences tx from batch %d to batch %d (txSize %d > %d)",
+			firstSequence.BatchNumber, lastSequence.BatchNumber, txSize, s.cfg.MaxTxSizeForL1)
+		return ErrOversizedData
+	}
+	return nil
+}
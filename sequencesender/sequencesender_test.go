@@ -0,0 +1,177 @@
+package sequencesender
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sieniven/zkevm-nubit/etherman"
+	"github.com/sieniven/zkevm-nubit/etherman/types"
+	"github.com/sieniven/zkevm-nubit/ethtxmanager"
+	"github.com/sieniven/zkevm-nubit/sequencesender/batchsource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSequencesToSendFromBatchSource(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"1", "2", "3"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("0x0"+name), 0600)) //nolint:gomnd
+	}
+	s, err := New(Config{
+		MaxBatchesForL1:  2,
+		FirstBatchNumber: 1,
+		BatchSource:      batchsource.Config{Type: batchsource.TypeFile, Path: dir},
+	}, nil, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// The sequence is full once MaxBatchesForL1 is reached
+	sequences, err := s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 2)
+	assert.Equal(t, uint64(1), sequences[0].BatchNumber)
+	assert.Equal(t, []byte{1}, sequences[0].BatchL2Data)
+	assert.Equal(t, uint64(2), sequences[1].BatchNumber)
+	assert.False(t, sequences[1].IsSequenceTooBig)
+	assert.Equal(t, uint64(3), s.NextBatchNumber())
+
+	// The sequence stops at the last available batch
+	sequences, err = s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 1)
+	assert.Equal(t, []byte{3}, sequences[0].BatchL2Data)
+
+	sequences, err = s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, sequences)
+	assert.Equal(t, uint64(4), s.NextBatchNumber())
+}
+
+// newTestingSizedSender creates a sequence sender reading 5 batches, with the L1 tx size limited
+// to fit the given number of batches
+func newTestingSizedSender(t *testing.T, fittingBatches int, extraBytes int64) *SequenceSender {
+	t.Helper()
+	dir := t.TempDir()
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("%d", i)), []byte(fmt.Sprintf("0x0%d", i)), 0600)) //nolint:gomnd
+	}
+	cfg := Config{
+		MaxBatchesForL1:  10,
+		FirstBatchNumber: 1,
+		BatchSource:      batchsource.Config{Type: batchsource.TypeFile, Path: dir},
+	}
+
+	var maxTxSize uint64
+	if fittingBatches > 0 {
+		sequences := make([]types.Sequence, fittingBatches)
+		for i := range sequences {
+			sequences[i].BatchNumber = uint64(i + 1)
+		}
+		size, err := etherman.EstimateSequenceBatchesTxSize(sequences, 0, 0, cfg.L2Coinbase, make([]byte, daMessageSizeEstimate))
+		require.NoError(t, err)
+		maxTxSize = uint64(int64(size) + extraBytes)
+	} else {
+		maxTxSize = 1
+	}
+	cfg.MaxTxSizeForL1 = maxTxSize
+
+	s, err := New(cfg, nil, nil)
+	require.NoError(t, err)
+	return s
+}
+
+func TestGetSequencesToSendMaxTxSize(t *testing.T) {
+	ctx := context.Background()
+
+	// The L1 tx of 3 batches fits exactly
+	s := newTestingSizedSender(t, 3, 0)
+	sequences, err := s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 3)
+	assert.Equal(t, uint64(4), s.NextBatchNumber())
+	// The sequence was closed by the 4th batch, so the last one is flagged
+	assert.False(t, sequences[0].IsSequenceTooBig)
+	assert.False(t, sequences[1].IsSequenceTooBig)
+	assert.True(t, sequences[2].IsSequenceTooBig)
+
+	// The batch exceeding the limit by a byte is left for the next sequence
+	s = newTestingSizedSender(t, 3, -1)
+	sequences, err = s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 2)
+	assert.Equal(t, uint64(3), s.NextBatchNumber())
+	assert.True(t, sequences[1].IsSequenceTooBig)
+	sequences, err = s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 2)
+	assert.Equal(t, uint64(3), sequences[0].BatchNumber)
+
+	// A single batch too big to be sent is not consumed
+	s = newTestingSizedSender(t, 0, 0)
+	sequences, err = s.getSequencesToSend(ctx)
+	require.ErrorIs(t, err, ErrOversizedData)
+	assert.ErrorContains(t, err, "batch 1 is too big")
+	assert.Empty(t, sequences)
+	assert.Equal(t, uint64(1), s.NextBatchNumber())
+}
+
+func TestStartStopsOnOversizedBatch(t *testing.T) {
+	s := newTestingSizedSender(t, 0, 0)
+	manager, err := ethtxmanager.New(ethtxmanager.Config{}, nil)
+	require.NoError(t, err)
+	s.ethTxManager = manager
+	s.TriggerSend()
+
+	// The batch can never be sequenced, so the sequence sender stops instead of retrying it
+	err = s.Start(context.Background())
+	require.ErrorIs(t, err, ErrOversizedData)
+	assert.ErrorContains(t, err, "batch 1 is too big")
+	assert.Equal(t, uint64(1), s.NextBatchNumber())
+}
+
+func TestGetSequencesToSendUnlimitedTxSize(t *testing.T) {
+	s := newTestingSizedSender(t, 1, 0)
+	s.cfg.MaxTxSizeForL1 = 0
+	sequences, err := s.getSequencesToSend(context.Background())
+	require.NoError(t, err)
+	require.Len(t, sequences, 5)
+}
+
+func TestNewRequiresForcedGasForPendingSequences(t *testing.T) {
+	manager, err := ethtxmanager.New(ethtxmanager.Config{}, nil)
+	require.NoError(t, err)
+	_, err = New(Config{MaxPendingSequences: 2}, nil, manager)
+	assert.ErrorIs(t, err, ErrPendingSequencesWithoutForcedGas)
+	_, err = New(Config{MaxPendingSequences: 1}, nil, manager)
+	assert.NoError(t, err)
+
+	manager, err = ethtxmanager.New(ethtxmanager.Config{ForcedGas: 1000000}, nil)
+	require.NoError(t, err)
+	_, err = New(Config{MaxPendingSequences: 2}, nil, manager)
+	assert.NoError(t, err)
+}
+
+func TestPipelineReady(t *testing.T) {
+	s, err := New(Config{MaxPendingSequences: 2}, nil, nil)
+	require.NoError(t, err)
+
+	// Sequences are posted until the in-flight ones reach the limit
+	assert.True(t, s.pipelineReady(0, false))
+	assert.True(t, s.pipelineReady(1, false))
+	assert.False(t, s.pipelineReady(2, false))
+
+	// After a failure, nothing is posted until the sequences sent after it are done
+	assert.False(t, s.pipelineReady(1, true))
+	assert.False(t, s.pipelineReady(1, false))
+	assert.False(t, s.pipelineReady(0, false))
+	assert.True(t, s.pipelineReady(0, false))
+
+	// A zero limit sends one sequence at a time
+	s, err = New(Config{}, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, s.pipelineReady(0, false))
+	assert.False(t, s.pipelineReady(1, false))
+}
@@ -0,0 +1,85 @@
+package sequencesender
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/sieniven/zkevm-nubit/log"
+)
+
+// clock tells the current time, so the time based policies can be tested
+type clock interface {
+	Now() time.Time
+}
+
+// systemClock is the clock of the system
+type systemClock struct{}
+
+// Now returns the current system time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// l1GasPricer returns the current L1 gas price
+type l1GasPricer interface {
+	GetL1GasPrice(ctx context.Context) *big.Int
+}
+
+// triggersEnabled checks if any policy closing a sequence is enabled
+func (cfg TriggersConfig) triggersEnabled() bool {
+	return cfg.MinPendingBatches > 0 || cfg.MinPendingBytes > 0 || cfg.MaxPendingBatchAge.Duration > 0
+}
+
+// shouldCloseSequence checks if the pending batches trigger any policy closing a sequence. The
+// policies on the number and size of the pending batches are postponed while the L1 gas price is
+// above the ceiling, unlike the policy on the age of the oldest pending batch.
+func (s *SequenceSender) shouldCloseSequence(ctx context.Context) bool {
+	triggers := s.cfg.Triggers
+	if !triggers.triggersEnabled() {
+		return false
+	}
+
+	maxBatchesForL1, _ := s.BatchLimits()
+	err := s.readPendingBatches(ctx, maxBatchesForL1)
+	if err != nil {
+		log.Errorf("failed to read pending batches: %v", err)
+		return false
+	}
+	if len(s.pending) == 0 {
+		return false
+	}
+
+	age := s.clock.Now().Sub(s.pending[0].Timestamp)
+	if triggers.MaxPendingBatchAge.Duration > 0 && age >= triggers.MaxPendingBatchAge.Duration {
+		log.Infof("closing sequence, oldest pending batch %d is %v old", s.pending[0].BatchNumber, age)
+		return true
+	}
+
+	var reason string
+	if triggers.MinPendingBatches > 0 && uint64(len(s.pending)) >= triggers.MinPendingBatches {
+		reason = "pending batches reached MinPendingBatches"
+	}
+	if triggers.MinPendingBytes > 0 && reason == "" {
+		var pendingBytes uint64
+		for _, batch := range s.pending {
+			pendingBytes += uint64(len(batch.BatchL2Data))
+		}
+		if pendingBytes >= triggers.MinPendingBytes {
+			reason = "pending bytes reached MinPendingBytes"
+		}
+	}
+	if reason == "" {
+		return false
+	}
+
+	if triggers.MaxL1GasPrice > 0 && s.gasPricer != nil {
+		gasPrice := s.gasPricer.GetL1GasPrice(ctx)
+		if gasPrice != nil && gasPrice.Cmp(new(big.Int).SetUint64(triggers.MaxL1GasPrice)) > 0 {
+			log.Debugf("postponing sequence (%s), L1 gas price %v is above MaxL1GasPrice %d", reason, gasPrice, triggers.MaxL1GasPrice)
+			return false
+		}
+	}
+	log.Infof("closing sequence, %s", reason)
+	return true
+}
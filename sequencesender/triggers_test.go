@@ -0,0 +1,133 @@
+package sequencesender
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/sieniven/zkevm-nubit/sequencesender/batchsource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a clock set by the tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// fakeGasPricer returns the gas price set by the tests
+type fakeGasPricer struct {
+	gasPrice *big.Int
+}
+
+func (p *fakeGasPricer) GetL1GasPrice(ctx context.Context) *big.Int {
+	return p.gasPrice
+}
+
+// newTestingTriggersSender creates a sequence sender with a JSONL batch source holding a batch
+// of 10 bytes every minute from the start time
+func newTestingTriggersSender(t *testing.T, batches int, start time.Time, triggers TriggersConfig) (*SequenceSender, *fakeClock, *fakeGasPricer) {
+	t.Helper()
+	var lines []string
+	for i := 0; i < batches; i++ {
+		timestamp := start.Add(time.Duration(i) * time.Minute).Unix()
+		lines = append(lines, fmt.Sprintf(`{"batchL2Data":"0x%s","timestamp":"0x%x"}`, strings.Repeat("aa", 10), timestamp))
+	}
+	path := filepath.Join(t.TempDir(), "batches.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600)) //nolint:gomnd
+
+	s, err := New(Config{
+		MaxBatchesForL1:  10,
+		FirstBatchNumber: 1,
+		BatchSource:      batchsource.Config{Type: batchsource.TypeFile, Path: path},
+		Triggers:         triggers,
+	}, nil, nil)
+	require.NoError(t, err)
+	clock := &fakeClock{now: start}
+	gasPricer := &fakeGasPricer{gasPrice: big.NewInt(1)}
+	s.clock = clock
+	s.gasPricer = gasPricer
+	return s, clock, gasPricer
+}
+
+func TestTriggersDisabled(t *testing.T) {
+	s, clock, _ := newTestingTriggersSender(t, 5, time.Unix(1000, 0), TriggersConfig{})
+	clock.now = clock.now.Add(time.Hour)
+	assert.False(t, s.shouldCloseSequence(context.Background()))
+	assert.Empty(t, s.pending)
+}
+
+func TestTriggerMinPendingBatches(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestingTriggersSender(t, 3, time.Unix(1000, 0), TriggersConfig{MinPendingBatches: 3})
+	assert.True(t, s.shouldCloseSequence(ctx))
+
+	s, _, _ = newTestingTriggersSender(t, 2, time.Unix(1000, 0), TriggersConfig{MinPendingBatches: 3})
+	assert.False(t, s.shouldCloseSequence(ctx))
+
+	// The pending batches are sequenced without being read again
+	sequences, err := s.getSequencesToSend(ctx)
+	require.NoError(t, err)
+	require.Len(t, sequences, 2)
+	assert.Equal(t, uint64(1), sequences[0].BatchNumber)
+	assert.Empty(t, s.pending)
+	assert.False(t, s.shouldCloseSequence(ctx))
+}
+
+func TestTriggerMinPendingBytes(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestingTriggersSender(t, 3, time.Unix(1000, 0), TriggersConfig{MinPendingBytes: 30})
+	assert.True(t, s.shouldCloseSequence(ctx))
+
+	s, _, _ = newTestingTriggersSender(t, 3, time.Unix(1000, 0), TriggersConfig{MinPendingBytes: 31})
+	assert.False(t, s.shouldCloseSequence(ctx))
+}
+
+func TestTriggerMaxPendingBatchAge(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+	s, clock, _ := newTestingTriggersSender(t, 2, start, TriggersConfig{MaxPendingBatchAge: types.NewDuration(5 * time.Minute)})
+
+	clock.now = start.Add(5*time.Minute - time.Second)
+	assert.False(t, s.shouldCloseSequence(ctx))
+	clock.now = start.Add(5 * time.Minute)
+	assert.True(t, s.shouldCloseSequence(ctx))
+
+	// The age is the one of the oldest batch still pending
+	s.pending = s.pending[1:]
+	s.lastBatchNum++
+	assert.False(t, s.shouldCloseSequence(ctx))
+	clock.now = start.Add(6 * time.Minute)
+	assert.True(t, s.shouldCloseSequence(ctx))
+}
+
+func TestTriggerMaxL1GasPrice(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+	s, clock, gasPricer := newTestingTriggersSender(t, 3, start, TriggersConfig{
+		MinPendingBatches:  3,
+		MaxPendingBatchAge: types.NewDuration(time.Hour),
+		MaxL1GasPrice:      100,
+	})
+
+	gasPricer.gasPrice = big.NewInt(100)
+	assert.True(t, s.shouldCloseSequence(ctx))
+
+	// Non urgent sequences are postponed while the gas price is too high
+	gasPricer.gasPrice = big.NewInt(101)
+	assert.False(t, s.shouldCloseSequence(ctx))
+
+	// Old batches are sequenced regardless of the gas price
+	clock.now = start.Add(time.Hour)
+	assert.True(t, s.shouldCloseSequence(ctx))
+}
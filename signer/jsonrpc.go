@@ -0,0 +1,146 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// JSONRPCFlavor is the JSON-RPC API exposed by a JSON-RPC signer
+type JSONRPCFlavor string
+
+const (
+	// JSONRPCFlavorClef signs the txs with the account_signTransaction method of Clef
+	JSONRPCFlavorClef JSONRPCFlavor = "clef"
+	// JSONRPCFlavorWeb3Signer signs the txs with the eth_signTransaction method of web3signer
+	JSONRPCFlavorWeb3Signer JSONRPCFlavor = "web3signer"
+)
+
+// JSONRPCSigner signs the txs with a Clef or web3signer style JSON-RPC signer. These signers
+// only sign txs and prefixed messages, so raw hashes can not be signed with them.
+type JSONRPCSigner struct {
+	client  *rpc.Client
+	address common.Address
+	method  string
+	timeout time.Duration
+	chainID *big.Int
+}
+
+// clefSignTxResult is the result of the account_signTransaction method of Clef
+type clefSignTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// NewJSONRPCSigner creates a signer for the address using the JSON-RPC signer at the URL
+func NewJSONRPCSigner(url string, address common.Address, flavor JSONRPCFlavor, timeout time.Duration, chainID uint64) (*JSONRPCSigner, error) {
+	var method string
+	switch flavor {
+	case JSONRPCFlavorClef, "":
+		method = "account_signTransaction"
+	case JSONRPCFlavorWeb3Signer:
+		method = "eth_signTransaction"
+	default:
+		return nil, fmt.Errorf("unsupported JSON-RPC signer flavor: %s", flavor)
+	}
+	if url == "" {
+		return nil, errors.New("JSON-RPC signer URL not configured")
+	}
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to JSON-RPC signer %s: %w", url, err)
+	}
+	return &JSONRPCSigner{
+		client:  client,
+		address: address,
+		method:  method,
+		timeout: timeout,
+		chainID: new(big.Int).SetUint64(chainID),
+	}, nil
+}
+
+// Address returns the address the JSON-RPC signer signs for
+func (s *JSONRPCSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs the tx with the JSON-RPC signer, and checks the signed tx is the requested
+// one signed by the signer address
+func (s *JSONRPCSigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	args, err := s.txArgs(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	var raw hexutil.Bytes
+	if s.method == "account_signTransaction" {
+		var result clefSignTxResult
+		err = s.client.CallContext(ctx, &result, s.method, args)
+		raw = result.Raw
+	} else {
+		err = s.client.CallContext(ctx, &raw, s.method, args)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx with JSON-RPC signer: %w", err)
+	}
+
+	signedTx := new(types.Transaction)
+	err = signedTx.UnmarshalBinary(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tx signed by JSON-RPC signer: %w", err)
+	}
+	txSigner := types.LatestSignerForChainID(s.chainID)
+	if txSigner.Hash(signedTx) != txSigner.Hash(tx) {
+		return nil, errors.New("JSON-RPC signer signed a different tx")
+	}
+	sender, err := types.Sender(txSigner, signedTx)
+	if err != nil {
+		return nil, err
+	}
+	if sender != s.address {
+		return nil, ErrUnexpectedSigner
+	}
+	return signedTx, nil
+}
+
+// SignHash is not supported by the JSON-RPC signers
+func (s *JSONRPCSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	return nil, ErrHashSigningUnsupported
+}
+
+// txArgs builds the tx arguments of the sign tx methods
+func (s *JSONRPCSigner) txArgs(tx *types.Transaction) (map[string]interface{}, error) {
+	args := map[string]interface{}{
+		"from":    s.address,
+		"gas":     hexutil.Uint64(tx.Gas()),
+		"value":   (*hexutil.Big)(tx.Value()),
+		"nonce":   hexutil.Uint64(tx.Nonce()),
+		"data":    hexutil.Bytes(tx.Data()),
+		"chainId": (*hexutil.Big)(s.chainID),
+	}
+	if tx.To() != nil {
+		args["to"] = tx.To()
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		args["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+		args["accessList"] = tx.AccessList()
+	case types.DynamicFeeTxType:
+		args["maxFeePerGas"] = (*hexutil.Big)(tx.GasFeeCap())
+		args["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.GasTipCap())
+		args["accessList"] = tx.AccessList()
+	default:
+		return nil, fmt.Errorf("tx type %d not supported by JSON-RPC signers", tx.Type())
+	}
+	return args, nil
+}
@@ -0,0 +1,65 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PrivateKeySigner signs with a private key kept in memory
+type PrivateKeySigner struct {
+	privKey *ecdsa.PrivateKey
+	address common.Address
+	chainID *big.Int
+}
+
+// NewPrivateKeySigner creates a signer for the private key
+func NewPrivateKeySigner(privKey *ecdsa.PrivateKey, chainID uint64) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		privKey: privKey,
+		address: crypto.PubkeyToAddress(privKey.PublicKey),
+		chainID: new(big.Int).SetUint64(chainID),
+	}
+}
+
+// NewKeystoreSigner creates a signer for the private key of the keystore file
+func NewKeystoreSigner(path, password string, chainID uint64) (*PrivateKeySigner, error) {
+	if path == "" {
+		return nil, errors.New("keystore file not configured")
+	}
+	fmt.Printf("reading key from: %v\n", path)
+	keystoreEncrypted, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("decrypting key from: %v\n", path)
+	key, err := keystore.DecryptKey(keystoreEncrypted, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKeySigner(key.PrivateKey, chainID), nil
+}
+
+// Address returns the address of the private key
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs the tx with the private key
+func (s *PrivateKeySigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(s.chainID), s.privKey)
+}
+
+// SignHash signs the raw hash with the private key
+func (s *PrivateKeySigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.privKey)
+}
@@ -0,0 +1,96 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// RemoteSignPath is the path of the remote HTTP signer endpoint signing raw hashes.
+//
+// The remote HTTP signer protocol is a single endpoint receiving a POST request with the
+// JSON body {"address": "0x...", "hash": "0x..."}, where the hash is 32 bytes. It answers
+// with the JSON body {"signature": "0x..."}, where the signature is 65 bytes in the
+// [R || S || V] format, V being 0, 1, 27 or 28. The txs are signed by signing their
+// signature hash, so the remote signer only needs to hold the keys.
+const RemoteSignPath = "/sign"
+
+// RemoteSignRequest is the request of the remote HTTP signer protocol
+type RemoteSignRequest struct {
+	Address common.Address `json:"address"`
+	Hash    hexutil.Bytes  `json:"hash"`
+}
+
+// RemoteSignResponse is the response of the remote HTTP signer protocol
+type RemoteSignResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// RemoteSigner signs with a remote HTTP signer
+type RemoteSigner struct {
+	client  *http.Client
+	url     string
+	address common.Address
+	chainID uint64
+}
+
+// NewRemoteSigner creates a signer for the address using the remote HTTP signer at the URL
+func NewRemoteSigner(url string, address common.Address, timeout time.Duration, chainID uint64) (*RemoteSigner, error) {
+	if url == "" {
+		return nil, errors.New("remote signer URL not configured")
+	}
+	return &RemoteSigner{
+		client:  &http.Client{Timeout: timeout},
+		url:     strings.TrimSuffix(url, "/") + RemoteSignPath,
+		address: address,
+		chainID: chainID,
+	}, nil
+}
+
+// Address returns the address the remote signer signs for
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs the tx by signing its signature hash with the remote signer
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return signTxWithHashSigner(ctx, s, s.chainID, tx)
+}
+
+// SignHash signs the raw hash with the remote signer, and checks the signature was made
+// by the signer address
+func (s *RemoteSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	body, err := json.Marshal(RemoteSignRequest{Address: s.address, Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with remote signer: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %v from remote signer", res.StatusCode)
+	}
+
+	var signResponse RemoteSignResponse
+	err = json.NewDecoder(res.Body).Decode(&signResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode remote signer response: %w", err)
+	}
+	return verifySignature(s.address, hash, signResponse.Signature)
+}
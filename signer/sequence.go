@@ -0,0 +1,22 @@
+package signer
+
+import (
+	"context"
+
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignSequence signs the accumulated input hash of the sequence, producing the same
+// signature as daTypes.Sequence.Sign with the private key of the signer
+func SignSequence(ctx context.Context, s Signer, sequence daTypes.Sequence) (*daTypes.SignedSequence, error) {
+	signature, err := s.SignHash(ctx, sequence.HashToSign())
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27 //nolint:gomnd
+	return &daTypes.SignedSequence{
+		Sequence:  sequence,
+		Signature: signature,
+	}, nil
+}
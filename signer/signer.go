@@ -0,0 +1,114 @@
+// Package signer abstracts the keys signing the L1 txs and the data availability
+// messages, so they can be kept in a local keystore file or in an external signer.
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	configTypes "github.com/sieniven/zkevm-nubit/config/types"
+)
+
+// Type is the type of a signer
+type Type string
+
+const (
+	// TypeKeystore signs with the private key of a local keystore file
+	TypeKeystore Type = "keystore"
+	// TypeJSONRPC signs with a Clef or web3signer style JSON-RPC signer, except the blob txs
+	TypeJSONRPC Type = "jsonrpc"
+	// TypeRemote signs with a remote HTTP signer
+	TypeRemote Type = "remote"
+)
+
+// DefaultTimeout is the timeout of the requests to the external signers
+const DefaultTimeout = 10 * time.Second
+
+var (
+	// ErrHashSigningUnsupported when the signer can not sign raw hashes
+	ErrHashSigningUnsupported = errors.New("signer does not support signing raw hashes")
+	// ErrUnexpectedSigner when the signature was not made by the signer address
+	ErrUnexpectedSigner = errors.New("signature does not match the signer address")
+)
+
+// Signer signs txs and raw hashes on behalf of an address
+type Signer interface {
+	// Address returns the address of the signer
+	Address() common.Address
+	// SignTx signs the tx
+	SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error)
+	// SignHash signs the raw hash, without any prefix. The signature is returned in the
+	// [R || S || V] format, where V is 0 or 1
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// Config represents the configuration of a signer
+type Config struct {
+	// Type is the type of the signer, keystore by default
+	Type Type `mapstructure:"Type"`
+	// URL is the URL of the external signer
+	URL string `mapstructure:"URL"`
+	// Address is the address the external signer signs for
+	Address common.Address `mapstructure:"Address"`
+	// Flavor is the JSON-RPC API of a JSON-RPC signer, clef or web3signer
+	Flavor JSONRPCFlavor `mapstructure:"Flavor"`
+	// Timeout is the timeout of the requests to the external signer
+	Timeout configTypes.Duration `mapstructure:"Timeout"`
+}
+
+// New creates the signer of the configuration. The keystore file is only used by the
+// keystore signers, and the chain ID is used to sign the txs.
+func New(cfg Config, keystore configTypes.KeystoreFileConfig, chainID uint64) (Signer, error) {
+	timeout := cfg.Timeout.Duration
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	switch cfg.Type {
+	case TypeKeystore, "":
+		return NewKeystoreSigner(keystore.Path, keystore.Password, chainID)
+	case TypeJSONRPC:
+		return NewJSONRPCSigner(cfg.URL, cfg.Address, cfg.Flavor, timeout, chainID)
+	case TypeRemote:
+		return NewRemoteSigner(cfg.URL, cfg.Address, timeout, chainID)
+	default:
+		return nil, fmt.Errorf("unsupported signer type: %s", cfg.Type)
+	}
+}
+
+// signTxWithHashSigner signs the tx by signing its signature hash
+func signTxWithHashSigner(ctx context.Context, s Signer, chainID uint64, tx *types.Transaction) (*types.Transaction, error) {
+	txSigner := types.LatestSignerForChainID(new(big.Int).SetUint64(chainID))
+	signature, err := s.SignHash(ctx, txSigner.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(txSigner, signature)
+}
+
+// verifySignature checks that the signature of the hash was made by the address, and
+// returns the signature with V normalized to 0 or 1
+func verifySignature(address common.Address, hash, signature []byte) ([]byte, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 { //nolint:gomnd
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.PubkeyToAddress(*pubKey) != address {
+		return nil, ErrUnexpectedSigner
+	}
+	return sig, nil
+}
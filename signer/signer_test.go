@@ -0,0 +1,255 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	configTypes "github.com/sieniven/zkevm-nubit/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = 1337
+
+func newTestingKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func newTestingTxs() []*types.Transaction {
+	to := common.HexToAddress("0x2")
+	return []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(10), Gas: 21000, To: &to, Value: big.NewInt(5), Data: []byte{1}}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(testChainID), Nonce: 2, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(20), Gas: 30000, To: &to, Data: []byte{2}}),
+	}
+}
+
+// assertSigner checks that the signer signs txs, hashes and sequences for its address
+func assertSigner(t *testing.T, s Signer, address common.Address, signsHashes bool) {
+	t.Helper()
+	ctx := context.Background()
+	assert.Equal(t, address, s.Address())
+
+	txSigner := types.LatestSignerForChainID(big.NewInt(testChainID))
+	for _, tx := range newTestingTxs() {
+		signedTx, err := s.SignTx(ctx, tx)
+		require.NoError(t, err)
+		sender, err := types.Sender(txSigner, signedTx)
+		require.NoError(t, err)
+		assert.Equal(t, address, sender)
+		assert.Equal(t, txSigner.Hash(tx), txSigner.Hash(signedTx))
+	}
+
+	sequence := daTypes.Sequence{[]byte{1, 2, 3}, []byte{4, 5}}
+	signedSequence, err := SignSequence(ctx, s, sequence)
+	if !signsHashes {
+		require.ErrorIs(t, err, ErrHashSigningUnsupported)
+		return
+	}
+	require.NoError(t, err)
+	sequenceSigner, err := signedSequence.Signer()
+	require.NoError(t, err)
+	assert.Equal(t, address, sequenceSigner)
+}
+
+func TestPrivateKeySigner(t *testing.T) {
+	key := newTestingKey(t)
+	s := NewPrivateKeySigner(key, testChainID)
+	assertSigner(t, s, crypto.PubkeyToAddress(key.PublicKey), true)
+
+	// The sequence signature matches the one of the private key
+	sequence := daTypes.Sequence{[]byte{1, 2, 3}}
+	expected, err := sequence.Sign(key)
+	require.NoError(t, err)
+	actual, err := SignSequence(context.Background(), s, sequence)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Signature, actual.Signature)
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	key := newTestingKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	encrypted, err := keystore.EncryptKey(&keystore.Key{Address: address, PrivateKey: key}, "password", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.keystore")
+	require.NoError(t, os.WriteFile(path, encrypted, 0600)) //nolint:gomnd
+
+	s, err := New(Config{}, configTypes.KeystoreFileConfig{Path: path, Password: "password"}, testChainID)
+	require.NoError(t, err)
+	assertSigner(t, s, address, true)
+
+	_, err = New(Config{Type: TypeKeystore}, configTypes.KeystoreFileConfig{Path: path, Password: "wrong"}, testChainID)
+	require.Error(t, err)
+	_, err = New(Config{Type: TypeKeystore}, configTypes.KeystoreFileConfig{}, testChainID)
+	require.Error(t, err)
+}
+
+// newStubRemoteSigner starts a remote HTTP signer signing with the key
+func newStubRemoteSigner(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != RemoteSignPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req RemoteSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		signature, err := crypto.Sign(req.Hash, key)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// answer with the V of the Ethereum yellow paper
+		signature[crypto.RecoveryIDOffset] += 27
+		_ = json.NewEncoder(w).Encode(RemoteSignResponse{Signature: signature})
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key := newTestingKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	url := newStubRemoteSigner(t, key)
+
+	s, err := New(Config{Type: TypeRemote, URL: url, Address: address}, configTypes.KeystoreFileConfig{}, testChainID)
+	require.NoError(t, err)
+	assertSigner(t, s, address, true)
+
+	// The remote signer holds the key of another address
+	other := crypto.PubkeyToAddress(newTestingKey(t).PublicKey)
+	s, err = NewRemoteSigner(url, other, time.Second, testChainID)
+	require.NoError(t, err)
+	_, err = s.SignTx(context.Background(), newTestingTxs()[0])
+	require.ErrorIs(t, err, ErrUnexpectedSigner)
+
+	// The remote signer is not available
+	s, err = NewRemoteSigner(url+"/unknown", address, time.Second, testChainID)
+	require.NoError(t, err)
+	_, err = s.SignHash(context.Background(), crypto.Keccak256([]byte("hash")))
+	require.Error(t, err)
+}
+
+// stubTxArgs are the tx arguments received by the stub JSON-RPC signer
+type stubTxArgs struct {
+	To                   *common.Address `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// stubJSONRPCSigner signs the txs with the key, exposing both the Clef and web3signer methods
+type stubJSONRPCSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *stubJSONRPCSigner) sign(args stubTxArgs) (hexutil.Bytes, error) {
+	var tx *types.Transaction
+	if args.MaxFeePerGas != nil {
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID: args.ChainID.ToInt(), Nonce: uint64(args.Nonce), Gas: uint64(args.Gas), To: args.To,
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(), GasFeeCap: args.MaxFeePerGas.ToInt(),
+			Value: args.Value.ToInt(), Data: args.Data,
+		})
+	} else {
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce: uint64(args.Nonce), Gas: uint64(args.Gas), To: args.To,
+			GasPrice: args.GasPrice.ToInt(), Value: args.Value.ToInt(), Data: args.Data,
+		})
+	}
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signedTx.MarshalBinary()
+}
+
+type stubClefAPI struct{ *stubJSONRPCSigner }
+
+func (s *stubClefAPI) SignTransaction(args stubTxArgs) (*clefSignTxResult, error) {
+	raw, err := s.sign(args)
+	if err != nil {
+		return nil, err
+	}
+	return &clefSignTxResult{Raw: raw}, nil
+}
+
+type stubWeb3SignerAPI struct{ *stubJSONRPCSigner }
+
+func (s *stubWeb3SignerAPI) SignTransaction(args stubTxArgs) (hexutil.Bytes, error) {
+	return s.sign(args)
+}
+
+// newStubJSONRPCSigner starts a JSON-RPC signer signing with the key
+func newStubJSONRPCSigner(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	stub := &stubJSONRPCSigner{key: key}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", &stubClefAPI{stub}))
+	require.NoError(t, server.RegisterName("eth", &stubWeb3SignerAPI{stub}))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func TestJSONRPCSigner(t *testing.T) {
+	key := newTestingKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	url := newStubJSONRPCSigner(t, key)
+
+	for _, flavor := range []JSONRPCFlavor{JSONRPCFlavorClef, JSONRPCFlavorWeb3Signer} {
+		t.Run(string(flavor), func(t *testing.T) {
+			s, err := New(Config{Type: TypeJSONRPC, URL: url, Address: address, Flavor: flavor}, configTypes.KeystoreFileConfig{}, testChainID)
+			require.NoError(t, err)
+			assertSigner(t, s, address, false)
+
+			// The JSON-RPC signer holds the key of another address
+			other := crypto.PubkeyToAddress(newTestingKey(t).PublicKey)
+			s, err = NewJSONRPCSigner(url, other, flavor, time.Second, testChainID)
+			require.NoError(t, err)
+			_, err = s.SignTx(context.Background(), newTestingTxs()[1])
+			require.ErrorIs(t, err, ErrUnexpectedSigner)
+		})
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	invalid := []Config{
+		{Type: "pkcs11"},
+		{Type: TypeRemote},
+		{Type: TypeJSONRPC},
+		{Type: TypeJSONRPC, URL: "http://localhost:8550", Flavor: "vault"},
+	}
+	for _, cfg := range invalid {
+		_, err := New(cfg, configTypes.KeystoreFileConfig{}, testChainID)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,255 @@
+// Package testutil provides a simulated L1 with the validium rollup contracts deployed, to run
+// offline integration tests of the node subsystems.
+package testutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/sieniven/zkevm-nubit/etherman"
+	"github.com/sieniven/zkevm-nubit/etherman/smartcontracts/nubitdaverifier"
+	"github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygondatacommittee_xlayer"
+	"github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonrollupmanager"
+	polygonzkevm "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonvalidium_xlayer"
+	"github.com/sieniven/zkevm-nubit/signer"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// ChainID is the chain ID of the simulated L1
+	ChainID = 1337
+	// L2ChainID is the chain ID of the validium created in the rollup manager
+	L2ChainID = 1001
+	// ForkID is the fork ID of the validium rollup type
+	ForkID = 9
+
+	// legacyChainID is the chain ID of the legacy zkEVM the rollup manager is initialized with
+	legacyChainID = 1000
+	// blockGasLimit fits the deployment of the rollup manager
+	blockGasLimit = 30000000
+)
+
+var (
+	// stubCode is the init code of a contract returning uint256(1) to any call. It stands for the
+	// global exit root manager, the bridge, the POL token and the verifier of the rollups, as
+	// well as the legacy zkEVM the rollup manager is initialized with.
+	stubCode = common.FromHex("0x600a600c600039600a6000f3" + "600160005260206000f3")
+
+	// minimalProxyCodePrefix and minimalProxyCodeSuffix are the EIP-1167 minimal proxy init code, the
+	// implementation address goes between them. The rollup manager disables the initializers of its
+	// implementation so it is only usable behind a proxy.
+	minimalProxyCodePrefix = common.FromHex("0x3d602d80600a3d3981f3363d3d373d3d3d363d73")
+	minimalProxyCodeSuffix = common.FromHex("0x5af43d82803e903d91602b57fd5bf3")
+
+	// initialBalance is the balance of the funded keys, 10000000 ETH in wei
+	initialBalance, _ = new(big.Int).SetString("10000000000000000000000000", 10) //nolint:gomnd
+)
+
+// DeployFunc deploys a contract to the simulated L1 and returns its address
+type DeployFunc func(auth *bind.TransactOpts, backend *backends.SimulatedBackend) (common.Address, error)
+
+// Config configures the simulated L1
+type Config struct {
+	// FundedKeys is the number of funded keys created in addition to the admin key
+	FundedKeys int
+	// DeployDAProtocol deploys the data availability protocol of the validium. It defaults to
+	// DeployDataCommittee.
+	DeployDAProtocol DeployFunc
+}
+
+// SimulatedL1 is a simulated L1 with a validium created in the rollup manager
+type SimulatedL1 struct {
+	Backend *backends.SimulatedBackend
+	// Admin is the deployer of the contracts, the admin of the rollup manager and the validium
+	// and the trusted sequencer
+	Admin *ecdsa.PrivateKey
+	// Keys are funded keys without any role in the contracts
+	Keys []*ecdsa.PrivateKey
+
+	L1Config      etherman.L1Config
+	RollupManager *polygonrollupmanager.Polygonrollupmanager
+	Validium      *polygonzkevm.PolygonvalidiumXlayer
+	RollupID      uint32
+	// Stub is the address of the global exit root manager, the bridge, the POL token and the
+	// verifier
+	Stub       common.Address
+	DAProtocol common.Address
+	// Etherman is an etherman client of the simulated L1 able to sign with the admin key
+	Etherman *etherman.Client
+}
+
+// NewSimulatedL1 deploys the rollup manager behind a proxy, creates the validium from the bundled
+// bytecode and sets its data availability protocol. The first sequence of the validium starts at
+// batch 2, as batch 1 is sequenced by its initialization.
+func NewSimulatedL1(t testing.TB, cfg Config) *SimulatedL1 {
+	t.Helper()
+	if cfg.DeployDAProtocol == nil {
+		cfg.DeployDAProtocol = DeployDataCommittee
+	}
+
+	l1 := &SimulatedL1{Admin: newKey(t)}
+	genesisAlloc := core.GenesisAlloc{crypto.PubkeyToAddress(l1.Admin.PublicKey): {Balance: initialBalance}}
+	for i := 0; i < cfg.FundedKeys; i++ {
+		key := newKey(t)
+		l1.Keys = append(l1.Keys, key)
+		genesisAlloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: initialBalance}
+	}
+	l1.Backend = backends.NewSimulatedBackend(genesisAlloc, blockGasLimit) //nolint:staticcheck
+	t.Cleanup(func() { l1.Backend.Close() })
+	auth := l1.Auth(t, l1.Admin)
+
+	// Rollup manager
+	var err error
+	l1.Stub, err = deployCode(auth, l1.Backend, stubCode)
+	require.NoError(t, err)
+	implementation, tx, _, err := polygonrollupmanager.DeployPolygonrollupmanager(auth, l1.Backend, l1.Stub, l1.Stub, l1.Stub)
+	l1.mined(t, tx, err)
+	proxyCode := append(append(append([]byte{}, minimalProxyCodePrefix...), implementation.Bytes()...), minimalProxyCodeSuffix...)
+	rollupManagerAddr, err := deployCode(auth, l1.Backend, proxyCode)
+	require.NoError(t, err)
+	l1.RollupManager, err = polygonrollupmanager.NewPolygonrollupmanager(rollupManagerAddr, l1.Backend)
+	require.NoError(t, err)
+	tx, err = l1.RollupManager.Initialize(auth, auth.From, uint64(time.Hour.Seconds()), uint64(time.Hour.Seconds()),
+		auth.From, auth.From, auth.From, l1.Stub, l1.Stub, ForkID, legacyChainID)
+	l1.mined(t, tx, err)
+
+	// Validium
+	validiumImplementation, tx, _, err := polygonzkevm.DeployPolygonvalidiumXlayer(auth, l1.Backend, l1.Stub, l1.Stub, l1.Stub, rollupManagerAddr)
+	l1.mined(t, tx, err)
+	tx, err = l1.RollupManager.AddNewRollupType(auth, validiumImplementation, l1.Stub, ForkID, 0, common.Hash{}, "validium")
+	l1.mined(t, tx, err)
+	tx, err = l1.RollupManager.CreateNewRollup(auth, 1, L2ChainID, auth.From, auth.From, common.Address{}, "http://127.0.0.1:8123", "validium")
+	l1.mined(t, tx, err)
+	l1.RollupID, err = l1.RollupManager.ChainIDToRollupID(&bind.CallOpts{}, L2ChainID)
+	require.NoError(t, err)
+	rollupData, err := l1.RollupManager.RollupIDToRollupData(&bind.CallOpts{}, l1.RollupID)
+	require.NoError(t, err)
+	l1.Validium, err = polygonzkevm.NewPolygonvalidiumXlayer(rollupData.RollupContract, l1.Backend)
+	require.NoError(t, err)
+
+	// Data availability protocol
+	l1.DAProtocol, err = cfg.DeployDAProtocol(auth, l1.Backend)
+	require.NoError(t, err)
+	tx, err = l1.Validium.SetDataAvailabilityProtocol(auth, l1.DAProtocol)
+	l1.mined(t, tx, err)
+
+	l1.L1Config = etherman.L1Config{
+		L1ChainID:         ChainID,
+		ZkEVMAddr:         rollupData.RollupContract,
+		RollupManagerAddr: rollupManagerAddr,
+	}
+	l1.Etherman, err = etherman.NewClientFromEthClient(etherman.Config{}, l1.L1Config, l1.Backend)
+	require.NoError(t, err)
+	l1.Etherman.RollupID = l1.RollupID
+	l1.Etherman.AddSigner(signer.NewPrivateKeySigner(l1.Admin, ChainID))
+	return l1
+}
+
+// Auth returns the transactor of a key
+func (l1 *SimulatedL1) Auth(t testing.TB, key *ecdsa.PrivateKey) *bind.TransactOpts {
+	t.Helper()
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(ChainID))
+	require.NoError(t, err)
+	return auth
+}
+
+// Mine commits a block every period until the test ends, so the txs sent by the subsystems under
+// test get mined
+func (l1 *SimulatedL1) Mine(t testing.TB, period time.Duration) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l1.Backend.Commit()
+			}
+		}
+	}()
+}
+
+// mined commits the tx and requires it to succeed
+func (l1 *SimulatedL1) mined(t testing.TB, tx *types.Transaction, err error) {
+	t.Helper()
+	require.NoError(t, err)
+	l1.Backend.Commit()
+	receipt, err := l1.Backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "tx %s reverted", tx.Hash())
+}
+
+// DeployDataCommittee deploys the data committee with an empty committee, which accepts an empty
+// data availability message
+func DeployDataCommittee(auth *bind.TransactOpts, backend *backends.SimulatedBackend) (common.Address, error) {
+	address, _, dac, err := polygondatacommittee_xlayer.DeployPolygondatacommitteeXlayer(auth, backend)
+	if err != nil {
+		return common.Address{}, err
+	}
+	backend.Commit()
+	if _, err = dac.Initialize(auth); err != nil {
+		return common.Address{}, err
+	}
+	backend.Commit()
+	if _, err = dac.SetupCommittee(auth, big.NewInt(0), []string{}, []byte{}); err != nil {
+		return common.Address{}, err
+	}
+	backend.Commit()
+	return address, nil
+}
+
+// DeployNubitDAVerifier returns the deployment of the NubitDA verifier, accepting the data
+// availability messages signed by the sequencer
+func DeployNubitDAVerifier(sequencer common.Address) DeployFunc {
+	return func(auth *bind.TransactOpts, backend *backends.SimulatedBackend) (common.Address, error) {
+		address, _, _, err := nubitdaverifier.DeployNubitdaverifier(auth, backend, sequencer)
+		if err != nil {
+			return common.Address{}, err
+		}
+		backend.Commit()
+		return address, nil
+	}
+}
+
+// DeployStub deploys a contract returning uint256(1) to any call, e.g. a data availability
+// protocol accepting any message
+func DeployStub(auth *bind.TransactOpts, backend *backends.SimulatedBackend) (common.Address, error) {
+	return deployCode(auth, backend, stubCode)
+}
+
+// deployCode deploys the init code and commits it
+func deployCode(auth *bind.TransactOpts, backend *backends.SimulatedBackend, code []byte) (common.Address, error) {
+	address, _, _, err := bind.DeployContract(auth, abi.ABI{}, code, backend)
+	if err != nil {
+		return common.Address{}, err
+	}
+	backend.Commit()
+	return address, nil
+}
+
+func newKey(t testing.TB) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
@@ -0,0 +1,98 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	polygonzkevm "github.com/sieniven/zkevm-nubit/etherman/smartcontracts/polygonvalidium_xlayer"
+	ethmanTypes "github.com/sieniven/zkevm-nubit/etherman/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatedL1SequencesBatches(t *testing.T) {
+	l1 := NewSimulatedL1(t, Config{FundedKeys: 2})
+	require.Len(t, l1.Keys, 2)
+	balance, err := l1.Backend.BalanceAt(context.Background(), crypto.PubkeyToAddress(l1.Keys[1].PublicKey), nil)
+	require.NoError(t, err)
+	assert.Equal(t, initialBalance, balance)
+
+	admin := crypto.PubkeyToAddress(l1.Admin.PublicKey)
+	sequencer, err := l1.Validium.TrustedSequencer(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, admin, sequencer)
+	daProtocol, err := l1.Validium.DataAvailabilityProtocol(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, l1.DAProtocol, daProtocol)
+
+	// The empty data committee accepts an empty data availability message
+	batches := []polygonzkevm.PolygonValidiumEtrogValidiumBatchData{{TransactionsHash: crypto.Keccak256Hash([]byte{0x0b})}}
+	tx, err := l1.Validium.SequenceBatchesValidium(l1.Auth(t, l1.Admin), batches, 1, 1, admin, []byte{})
+	l1.mined(t, tx, err)
+
+	rollupData, err := l1.RollupManager.RollupIDToRollupData(&bind.CallOpts{}, l1.RollupID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), rollupData.LastBatchSequenced)
+	event, err := l1.Etherman.GetLastSequencedBatch(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), event.BatchNumber)
+	assert.Equal(t, tx.Hash(), event.TxHash)
+}
+
+func TestSimulatedL1ForcesBatches(t *testing.T) {
+	ctx := context.Background()
+	l1 := NewSimulatedL1(t, Config{})
+	admin := crypto.PubkeyToAddress(l1.Admin.PublicKey)
+
+	// The admin is the force batch address of the validium
+	fee, err := l1.Etherman.GetForcedBatchFee()
+	require.NoError(t, err)
+	transactions := []byte{0x0b, 0x01, 0x02}
+	tx, err := l1.Etherman.ForceBatch(admin, transactions, fee)
+	l1.mined(t, tx, err)
+	lastForceBatch, err := l1.Etherman.GetLastForceBatch()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastForceBatch)
+
+	// The forced transactions are read from the calldata of the EOA tx
+	forcedBatches, lastBlock, err := l1.Etherman.GetForcedBatches(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, forcedBatches, 1)
+	forcedBatch := forcedBatches[0]
+	assert.Equal(t, uint64(1), forcedBatch.ForcedBatchNumber)
+	assert.Equal(t, transactions, forcedBatch.Transactions)
+	assert.Equal(t, admin, forcedBatch.Sequencer)
+	assert.Equal(t, tx.Hash(), forcedBatch.TxHash)
+	header, err := l1.Backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, header.Number.Uint64(), lastBlock)
+	assert.Equal(t, lastBlock, forcedBatch.BlockNumber)
+	forcedBatchHash, err := l1.Etherman.GetForcedBatchHash(1)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash(forcedBatchHash), forcedBatch.Hash())
+
+	forcedBatches, _, err = l1.Etherman.GetForcedBatches(ctx, lastBlock+1)
+	require.NoError(t, err)
+	assert.Empty(t, forcedBatches)
+
+	// Anyone allowed to force batches sequences them once the timeout expires
+	tx, err = l1.Validium.SetForceBatchTimeout(l1.Auth(t, l1.Admin), 0)
+	l1.mined(t, tx, err)
+	tx, err = l1.Etherman.SequenceForceBatches(admin, []ethmanTypes.Sequence{{
+		BatchL2Data:          forcedBatch.Transactions,
+		GlobalExitRoot:       forcedBatch.GlobalExitRoot,
+		ForcedBatchTimestamp: forcedBatch.ForcedAt.Unix(),
+		PrevBlockHash:        forcedBatch.PrevBlockHash,
+	}})
+	l1.mined(t, tx, err)
+	lastForceBatchSequenced, err := l1.Etherman.GetLastForceBatchSequenced()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastForceBatchSequenced)
+	forcedBatchHash, err = l1.Etherman.GetForcedBatchHash(1)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{}, common.Hash(forcedBatchHash))
+}